@@ -241,29 +241,44 @@ return &service
 {{if .Crud}}{{template "crud" .}}{{end}}
 {{if .Toggler}}
 func (s *{{.Type}}Service) Toggle({{.Param}} *{{.Type}}) error {
-	return s.endpoint.Toggle({{.Param}})
+	return s.Typed().Toggle({{.Param}})
 }{{end}}
 `
 
 var crudTemplateContent = `
+// Typed returns the typed service for {{.Param}}s
+func (s *{{.Type}}Service) Typed() *{{if .Toggler}}TogglerService{{else}}Service{{end}}[{{.Type}}, *{{.Type}}] {
+	return New{{if .Toggler}}TogglerService{{else}}Service{{end}}[{{.Type}}](s.endpoint)
+}
+
 func (s *{{.Type}}Service) All({{.Param}}s *[]*{{.Type}}, params url.Values) error {
-	return s.endpoint.All({{.Param}}s, params)
+	all, err := s.Typed().All(params)
+	if all != nil {
+		*{{.Param}}s = all
+	}
+	return err
 }
 
 func (s *{{.Type}}Service) Find(id int, {{.Param}} *{{.Type}}, params url.Values) error {
-	return s.endpoint.Find(id, {{.Param}}, params)
+	found, err := s.Typed().Find(id, params)
+	if found != nil {
+		*{{.Param}} = *found
+	}
+	return err
 }
 
 func (s *{{.Type}}Service) Create({{.Param}} *{{.Type}}) error {
-	return s.endpoint.Create({{.Param}})
+	return s.Typed().Create({{.Param}})
 }
 
-func (s *{{.Type}}Service) Update({{.Param}} *{{.Type}}) error {
-	return s.endpoint.Update({{.Param}})
+// Update updates the given {{.Param}}. If fields are provided, only these
+// fields are sent to the API, see NewPatch.
+func (s *{{.Type}}Service) Update({{.Param}} *{{.Type}}, fields ...string) error {
+	return s.Typed().Update({{.Param}}, fields...)
 }
 
 func (s *{{.Type}}Service) Delete({{.Param}} *{{.Type}}) error {
-	return s.endpoint.Delete({{.Param}})
+	return s.Typed().Delete({{.Param}})
 }
 `
 
@@ -30,9 +30,15 @@ func main() {
 		fmt.Printf("%T: %v\n", err, err)
 		os.Exit(1)
 	}
-	timeframe := harvest.Timeframe{
-		StartDate: harvest.Date(2014, 01, 01, time.UTC),
-		EndDate:   harvest.Date(2014, 02, 07, time.UTC),
+	timeframeExpression := os.Getenv("HARVEST_TIMEFRAME")
+	if timeframeExpression == "" {
+		timeframeExpression = "2014-01-01..2014-02-07"
+	}
+	timeframe, err := harvest.ParseTimeframe(timeframeExpression, time.Now(), harvest.Monday)
+	if err != nil {
+		fmt.Printf("There was an error parsing the timeframe:\n")
+		fmt.Printf("%T: %v\n", err, err)
+		os.Exit(1)
 	}
 	params := harvest.Params{}
 	params.ForTimeframe(timeframe)
@@ -1,5 +1,7 @@
 package harvest
 
+import "time"
+
 type Account struct {
 	Company *Company     `json:"company,omitempty"`
 	User    *AccountUser `json:"user,omitempty"`
@@ -32,6 +34,18 @@ const (
 	Monday   WeekStartDay = "Monday"
 )
 
+// Weekday returns the time.Weekday of w. It defaults to time.Monday for
+// unknown values.
+func (w WeekStartDay) Weekday() time.Weekday {
+	switch w {
+	case Sunday:
+		return time.Sunday
+	case Saturday:
+		return time.Saturday
+	}
+	return time.Monday
+}
+
 type TimeFormat string
 
 const (
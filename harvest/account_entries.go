@@ -0,0 +1,262 @@
+package harvest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EntrySource determines over which resources Harvest.DayEntries fans out
+type EntrySource int
+
+const (
+	// EntriesByUser fetches the entries of every user
+	EntriesByUser EntrySource = iota
+	// EntriesByProject fetches the entries of every project
+	EntriesByProject
+)
+
+func (e EntrySource) String() string {
+	if e == EntriesByProject {
+		return "project"
+	}
+	return "user"
+}
+
+func (e EntrySource) scope() dayEntryScope {
+	if e == EntriesByProject {
+		return projectScope
+	}
+	return userScope
+}
+
+const (
+	defaultFanOutWorkers    = 4
+	defaultFanOutRetries    = 3
+	defaultFanOutRetryAfter = 15 * time.Second
+)
+
+// FanOutOptions configure Harvest.DayEntries
+type FanOutOptions struct {
+	// Source selects whether the entries are fetched per user or per project
+	Source EntrySource
+	// Workers is the number of concurrent requests, defaults to 4
+	Workers int
+	// MaxRetries is the number of retries per source if the rate limit is
+	// reached, defaults to 3. A negative value disables retries.
+	MaxRetries int
+	// IncludeInactive also fetches the entries of inactive users or projects
+	IncludeInactive bool
+}
+
+func (f FanOutOptions) workers() int {
+	if f.Workers <= 0 {
+		return defaultFanOutWorkers
+	}
+	return f.Workers
+}
+
+func (f FanOutOptions) maxRetries() int {
+	if f.MaxRetries == 0 {
+		return defaultFanOutRetries
+	}
+	if f.MaxRetries < 0 {
+		return 0
+	}
+	return f.MaxRetries
+}
+
+// SourceError is the error of fetching the entries of a single user or
+// project
+type SourceError struct {
+	Source EntrySource
+	Id     int
+	Err    error
+}
+
+func (s *SourceError) Error() string {
+	return fmt.Sprintf("%s %d: %v", s.Source, s.Id, s.Err)
+}
+
+// FanOutError is returned by Harvest.DayEntries if the entries of some
+// sources could not be fetched. The entries of all other sources are
+// returned nevertheless.
+type FanOutError struct {
+	Errors []*SourceError
+}
+
+func (f *FanOutError) Error() string {
+	messages := make([]string, len(f.Errors))
+	for i, e := range f.Errors {
+		messages[i] = e.Error()
+	}
+	return fmt.Sprintf("Failed to fetch entries of %d sources: %s", len(f.Errors), strings.Join(messages, "; "))
+}
+
+// sleep is replaced within tests
+var sleep = time.Sleep
+
+// rateLimitGate blocks all workers once one of them reached the rate limit
+type rateLimitGate struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+func (r *rateLimitGate) wait() {
+	r.mu.Lock()
+	until := r.until
+	r.mu.Unlock()
+	if d := until.Sub(time.Now()); d > 0 {
+		sleep(d)
+	}
+}
+
+func (r *rateLimitGate) close(retryAfter time.Duration) {
+	if retryAfter <= 0 {
+		retryAfter = defaultFanOutRetryAfter
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if until := time.Now().Add(retryAfter); until.After(r.until) {
+		r.until = until
+	}
+}
+
+// DayEntries returns the day entries of the whole account matching query. It
+// fetches the entries of all active users, or projects, with a bounded number
+// of concurrent requests, see FanOutOptions. Entries are de-duplicated by ID
+// and sorted by date and ID.
+//
+// If the rate limit is reached, all requests pause for the duration given by
+// the API and are retried. If the entries of some sources can't be fetched,
+// the entries of all other sources are returned together with a *FanOutError.
+func (h *Harvest) DayEntries(query DayEntryQuery, options FanOutOptions) ([]*DayEntry, error) {
+	if _, err := query.values(options.Source.scope()); err != nil {
+		return nil, err
+	}
+	ids, err := h.entrySources(options)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make(chan int)
+	results := make(chan []*DayEntry)
+	errors := make(chan *SourceError)
+	gate := &rateLimitGate{}
+	var wg sync.WaitGroup
+	for i := 0; i < options.workers(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range sources {
+				entries, err := h.sourceEntries(id, query, options, gate)
+				if err != nil {
+					errors <- &SourceError{Source: options.Source, Id: id, Err: err}
+					continue
+				}
+				results <- entries
+			}
+		}()
+	}
+	go func() {
+		for _, id := range ids {
+			sources <- id
+		}
+		close(sources)
+		wg.Wait()
+		close(results)
+		close(errors)
+	}()
+
+	seen := make(map[int]bool)
+	var dayEntries []*DayEntry
+	fanOutError := &FanOutError{}
+	for results != nil || errors != nil {
+		select {
+		case entries, ok := <-results:
+			if !ok {
+				results = nil
+				continue
+			}
+			for _, entry := range entries {
+				if !seen[entry.ID] {
+					seen[entry.ID] = true
+					dayEntries = append(dayEntries, entry)
+				}
+			}
+		case err, ok := <-errors:
+			if !ok {
+				errors = nil
+				continue
+			}
+			fanOutError.Errors = append(fanOutError.Errors, err)
+		}
+	}
+	sort.Slice(dayEntries, func(i, j int) bool {
+		a, b := dayEntries[i], dayEntries[j]
+		if !a.SpentAt.Equal(b.SpentAt) {
+			return a.SpentAt.Before(b.SpentAt)
+		}
+		return a.ID < b.ID
+	})
+	if len(fanOutError.Errors) > 0 {
+		sort.Slice(fanOutError.Errors, func(i, j int) bool {
+			return fanOutError.Errors[i].Id < fanOutError.Errors[j].Id
+		})
+		return dayEntries, fanOutError
+	}
+	return dayEntries, nil
+}
+
+// entrySources returns the ids of the users or projects to fetch entries for
+func (h *Harvest) entrySources(options FanOutOptions) ([]int, error) {
+	var ids []int
+	if options.Source == EntriesByProject {
+		var projects []*Project
+		if err := h.Projects.All(&projects, nil); err != nil {
+			return nil, err
+		}
+		for _, project := range projects {
+			if project.Active || options.IncludeInactive {
+				ids = append(ids, project.ID)
+			}
+		}
+		return ids, nil
+	}
+	var users []*User
+	if err := h.Users.All(&users, nil); err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if user.IsActive || options.IncludeInactive {
+			ids = append(ids, user.ID)
+		}
+	}
+	return ids, nil
+}
+
+// sourceEntries fetches the entries of the user or project with id, retrying
+// if the rate limit is reached
+func (h *Harvest) sourceEntries(id int, query DayEntryQuery, options FanOutOptions, gate *rateLimitGate) ([]*DayEntry, error) {
+	var service *DayEntryService
+	if options.Source == EntriesByProject {
+		service = h.Projects.DayEntries(&Project{ID: id})
+	} else {
+		service = h.Users.DayEntries(&User{ID: id})
+	}
+	for attempt := 0; ; attempt++ {
+		gate.wait()
+		var entries []*DayEntry
+		err := service.Query(&entries, query)
+		if err == nil {
+			return entries, nil
+		}
+		rateLimitErr, ok := err.(RateLimitReached)
+		if !ok || !rateLimitErr.RateLimitReached() || attempt >= options.maxRetries() {
+			return nil, err
+		}
+		gate.close(rateLimitErr.RetryAfter())
+	}
+}
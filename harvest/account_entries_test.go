@@ -0,0 +1,151 @@
+package harvest
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fanOutEndpoint serves entries by path and records the requests
+type fanOutEndpoint struct {
+	CrudTogglerEndpoint
+	path    string
+	mu      *sync.Mutex
+	entries map[string][]*DayEntry
+	errors  map[string][]error
+	calls   map[string]int
+}
+
+func (f *fanOutEndpoint) Path() string {
+	return f.path
+}
+
+func (f *fanOutEndpoint) All(data interface{}, params url.Values) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[f.path]++
+	if errs := f.errors[f.path]; len(errs) > 0 {
+		f.errors[f.path] = errs[1:]
+		return errs[0]
+	}
+	*(data.(*[]*DayEntry)) = f.entries[f.path]
+	return nil
+}
+
+type fanOutProvider fanOutEndpoint
+
+// pathEndpoint adds a path to an endpoint
+type pathEndpoint struct {
+	CrudTogglerEndpoint
+	path string
+}
+
+func (p *pathEndpoint) Path() string {
+	return p.path
+}
+
+func (f *fanOutProvider) CrudEndpoint(path string) CrudEndpoint {
+	endpoint := fanOutEndpoint(*f)
+	endpoint.path = path
+	return &endpoint
+}
+
+func TestHarvestDayEntries(t *testing.T) {
+	var mu sync.Mutex
+	var slept []time.Duration
+	sleep = func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		slept = append(slept, d)
+	}
+	defer func() { sleep = time.Sleep }()
+
+	provider := &fanOutProvider{
+		mu: &mu,
+		entries: map[string][]*DayEntry{
+			"/people/1/entries": {
+				{ID: 3, UserId: 1, SpentAt: Date(2015, 1, 2)},
+				{ID: 1, UserId: 1, SpentAt: Date(2015, 1, 1)},
+			},
+			"/people/2/entries": {
+				{ID: 2, UserId: 2, SpentAt: Date(2015, 1, 1)},
+				{ID: 1, UserId: 1, SpentAt: Date(2015, 1, 1)},
+			},
+			"/people/3/entries": {
+				{ID: 4, UserId: 3, SpentAt: Date(2015, 1, 1)},
+			},
+		},
+		errors: map[string][]error{
+			"/people/2/entries": {NewRateLimitReachedError("", 10*time.Second)},
+			"/people/4/entries": {fmt.Errorf("boom")},
+		},
+		calls: make(map[string]int),
+	}
+	users := testApiAll(func(data interface{}, params url.Values) error {
+		*(data.(*[]*User)) = []*User{
+			{ID: 1, IsActive: true},
+			{ID: 2, IsActive: true},
+			{ID: 3, IsActive: false},
+			{ID: 4, IsActive: true},
+		}
+		return nil
+	})
+	h := &Harvest{Users: NewUserService(provider, &pathEndpoint{users, "/people"}, nil)}
+
+	query := DayEntryQuery{Timeframe: NewTimeframe(2015, 1, 1, 2015, 1, 31)}
+
+	entries, err := h.DayEntries(query, FanOutOptions{Workers: 2})
+
+	expectedEntries := []*DayEntry{
+		{ID: 1, UserId: 1, SpentAt: Date(2015, 1, 1)},
+		{ID: 2, UserId: 2, SpentAt: Date(2015, 1, 1)},
+		{ID: 3, UserId: 1, SpentAt: Date(2015, 1, 2)},
+	}
+
+	if !reflect.DeepEqual(expectedEntries, entries) {
+		t.Logf("Expected entries to equal\n%+v\n\tgot\n%+v\n", expectedEntries, entries)
+		t.Fail()
+	}
+
+	fanOutError, ok := err.(*FanOutError)
+	if !ok {
+		t.Logf("Expected error to be a *FanOutError, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	if len(fanOutError.Errors) != 1 || fanOutError.Errors[0].Id != 4 || fanOutError.Errors[0].Source != EntriesByUser {
+		t.Logf("Expected one error for user 4, got %v\n", fanOutError)
+		t.Fail()
+	}
+
+	if calls := provider.calls["/people/2/entries"]; calls != 2 {
+		t.Logf("Expected rate limited source to be retried once, got %d calls\n", calls)
+		t.Fail()
+	}
+
+	if calls := provider.calls["/people/3/entries"]; calls != 0 {
+		t.Logf("Expected inactive user not to be fetched, got %d calls\n", calls)
+		t.Fail()
+	}
+
+	if len(slept) == 0 {
+		t.Logf("Expected workers to pause after reaching the rate limit\n")
+		t.Fail()
+	}
+}
+
+func TestHarvestDayEntriesUnsupportedFilter(t *testing.T) {
+	h := &Harvest{}
+
+	query := DayEntryQuery{Timeframe: NewTimeframe(2015, 1, 1, 2015, 1, 31), UserId: 1}
+
+	_, err := h.DayEntries(query, FanOutOptions{Source: EntriesByUser})
+
+	if _, ok := err.(*ValidationError); !ok {
+		t.Logf("Expected error to be a *ValidationError, got %T: %v\n", err, err)
+		t.Fail()
+	}
+}
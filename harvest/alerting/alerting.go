@@ -0,0 +1,205 @@
+// Package alerting notifies about projects exceeding their budget.
+//
+// An Evaluator computes the budget consumption of all projects with
+// NotifyWhenOverBudget set and fires an alert once a threshold is crossed.
+// Fired alerts are remembered in a State, so that every alert is delivered
+// only once, and delivered by a Notifier.
+package alerting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mitch000001/go-harvest/harvest"
+	"github.com/mitch000001/go-harvest/harvest/reporting"
+)
+
+// Alert is fired if the budget consumption of a project crosses Threshold
+type Alert struct {
+	Project *harvest.Project
+	// Threshold is the crossed threshold in percent of the budget
+	Threshold float64
+	// Consumed is the consumed part of the budget in percent
+	Consumed float64
+	Budget   reporting.ProjectBudget
+	FiredAt  time.Time
+}
+
+// Key identifies the alert of a project for a threshold within a State
+func (a *Alert) Key() string {
+	return alertKey(a.Project.ID, a.Threshold)
+}
+
+func alertKey(projectId int, threshold float64) string {
+	return fmt.Sprintf("project/%d/%g", projectId, threshold)
+}
+
+// Subject returns a short description of the alert
+func (a *Alert) Subject() string {
+	if a.Threshold >= 100 {
+		return fmt.Sprintf("Project %s is over budget", a.Project.Name)
+	}
+	return fmt.Sprintf("Project %s has reached %g%% of its budget", a.Project.Name, a.Threshold)
+}
+
+// Message returns a description of the alert including the budget status
+func (a *Alert) Message() string {
+	var message strings.Builder
+	fmt.Fprintf(&message, "%s.\n\n", a.Subject())
+	fmt.Fprintf(&message, "Consumed: %.1f%%\n", a.Consumed)
+	if a.Budget.IsCostBudget() {
+		fmt.Fprintf(&message, "Budget: %s\nSpent: %s\nRemaining: %s\n", a.Budget.Cost.Budget, a.Budget.Cost.Spent, a.Budget.Cost.Remaining)
+	} else {
+		fmt.Fprintf(&message, "Budget: %s hours\nSpent: %s hours\nRemaining: %s hours\n", a.Budget.Hours.Budget, a.Budget.Hours.Spent, a.Budget.Hours.Remaining)
+	}
+	if !a.Budget.ExhaustedOn.IsZero() {
+		fmt.Fprintf(&message, "Exhausted on: %s\n", a.Budget.ExhaustedOn)
+	}
+	return message.String()
+}
+
+// Notifier delivers alerts
+type Notifier interface {
+	Notify(alert *Alert) error
+}
+
+// NotifierFunc is a function used as Notifier
+type NotifierFunc func(alert *Alert) error
+
+func (n NotifierFunc) Notify(alert *Alert) error {
+	return n(alert)
+}
+
+// Evaluator fires alerts for projects with NotifyWhenOverBudget set crossing
+// their budget thresholds, other projects are ignored. A project is alerted
+// once it is over budget and, if its OverBudgetNotificationPercentage is
+// between 0 and 100, once it reaches that percentage of its budget.
+type Evaluator struct {
+	Tracker  *reporting.BudgetTracker
+	State    State
+	Notifier Notifier
+	// Now returns the time alerts are fired at, defaults to time.Now
+	Now func() time.Time
+}
+
+func (e *Evaluator) now() time.Time {
+	if e.Now == nil {
+		return time.Now()
+	}
+	return e.Now()
+}
+
+// NotifyError is returned by Evaluator.Evaluate if alerts could not be
+// delivered. These alerts are not marked as fired and are retried by the next
+// evaluation.
+type NotifyError struct {
+	Errors map[string]error
+}
+
+func (n *NotifyError) Error() string {
+	keys := make([]string, 0, len(n.Errors))
+	for key := range n.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	messages := make([]string, len(keys))
+	for i, key := range keys {
+		messages[i] = fmt.Sprintf("%s: %v", key, n.Errors[key])
+	}
+	return fmt.Sprintf("Failed to deliver %d alerts: %s", len(keys), strings.Join(messages, "; "))
+}
+
+// Evaluate computes the budget consumption of all projects of the dataset and
+// delivers an alert for every threshold crossed which has not fired before.
+// It returns the delivered alerts.
+//
+// The consumption covers the whole lifetime of a project, so the dataset
+// should be loaded by reporting.LoadBudgets. Fired alerts of a project are
+// only reset if its budget changes, they fire again once the threshold of the
+// new budget is crossed.
+func (e *Evaluator) Evaluate(data *reporting.Dataset) ([]*Alert, error) {
+	var alerts []*Alert
+	notifyError := &NotifyError{Errors: make(map[string]error)}
+	for _, budget := range e.Tracker.Track(data) {
+		project := budget.Project
+		if !project.NotifyWhenOverBudget {
+			continue
+		}
+		if err := e.resetOnBudgetChange(budget); err != nil {
+			return alerts, err
+		}
+		consumed := budget.Consumed()
+		for _, threshold := range thresholds(project) {
+			if consumed < threshold {
+				continue
+			}
+			key := alertKey(project.ID, threshold)
+			fired, err := e.State.Fired(key)
+			if err != nil {
+				return alerts, err
+			}
+			if fired {
+				continue
+			}
+			alert := &Alert{
+				Project:   project,
+				Threshold: threshold,
+				Consumed:  consumed,
+				Budget:    budget,
+				FiredAt:   e.now(),
+			}
+			if err := e.Notifier.Notify(alert); err != nil {
+				notifyError.Errors[key] = err
+				continue
+			}
+			if err := e.State.MarkFired(key, alert.FiredAt); err != nil {
+				return alerts, err
+			}
+			alerts = append(alerts, alert)
+		}
+	}
+	if len(notifyError.Errors) > 0 {
+		return alerts, notifyError
+	}
+	return alerts, nil
+}
+
+// resetOnBudgetChange resets the alerts of the project if its budget differs
+// from the one recorded in the state and records the current budget
+func (e *Evaluator) resetOnBudgetChange(budget reporting.ProjectBudget) error {
+	project := budget.Project
+	value := budgetValue(budget)
+	previous, err := e.State.Budget(project.ID)
+	if err != nil || previous == value {
+		return err
+	}
+	if previous != "" {
+		for _, threshold := range thresholds(project) {
+			if err := e.State.Reset(alertKey(project.ID, threshold)); err != nil {
+				return err
+			}
+		}
+	}
+	return e.State.SetBudget(project.ID, value)
+}
+
+// budgetValue returns the budget of the project, the cost for cost budgets
+// and the hours otherwise
+func budgetValue(budget reporting.ProjectBudget) string {
+	if budget.IsCostBudget() {
+		return budget.Cost.Budget.String()
+	}
+	return budget.Hours.Budget.String() + " hours"
+}
+
+// thresholds returns the thresholds in percent for project in ascending
+// order
+func thresholds(project *harvest.Project) []float64 {
+	percentage := float64(project.OverBudgetNotificationPercentage)
+	if percentage > 0 && percentage < 100 {
+		return []float64{percentage, 100}
+	}
+	return []float64{100}
+}
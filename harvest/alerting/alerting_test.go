@@ -0,0 +1,104 @@
+package alerting
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mitch000001/go-harvest/harvest"
+	"github.com/mitch000001/go-harvest/harvest/reporting"
+)
+
+func testDataset(hours harvest.Hours, budget float64) *reporting.Dataset {
+	return &reporting.Dataset{
+		Timeframe: harvest.NewTimeframe(2015, 1, 5, 2015, 1, 18),
+		Projects: []*harvest.Project{
+			{ID: 1, Name: "Alerting", BudgetBy: harvest.BudgetByProject, Budget: budget, NotifyWhenOverBudget: true, OverBudgetNotificationPercentage: 80},
+			{ID: 2, Name: "Silent", BudgetBy: harvest.BudgetByProject, Budget: 1},
+		},
+		DayEntries: []*harvest.DayEntry{
+			{ID: 1, ProjectId: 1, Hours: hours, SpentAt: harvest.Date(2015, 1, 6)},
+			{ID: 2, ProjectId: 2, Hours: 5 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 6)},
+		},
+	}
+}
+
+func TestEvaluatorEvaluate(t *testing.T) {
+	var notified []string
+	evaluator := &Evaluator{
+		Tracker: &reporting.BudgetTracker{},
+		State:   NewMemoryState(),
+		Notifier: NotifierFunc(func(alert *Alert) error {
+			notified = append(notified, alert.Subject())
+			return nil
+		}),
+		Now: func() time.Time { return time.Date(2015, 1, 18, 12, 0, 0, 0, time.UTC) },
+	}
+
+	var tests = []struct {
+		hours    harvest.Hours
+		budget   float64
+		expected []string
+	}{
+		{7 * harvest.Hour, 10, nil},
+		{8 * harvest.Hour, 10, []string{"Project Alerting has reached 80% of its budget"}},
+		{9 * harvest.Hour, 10, nil},
+		{11 * harvest.Hour, 10, []string{"Project Alerting is over budget"}},
+		{12 * harvest.Hour, 10, nil},
+		// consumption falling, e.g. by moved entries, doesn't reset alerts
+		{5 * harvest.Hour, 10, nil},
+		{10 * harvest.Hour, 10, nil},
+		// budget raised and crossed again
+		{10 * harvest.Hour, 12, []string{"Project Alerting has reached 80% of its budget"}},
+		{12 * harvest.Hour, 12, []string{"Project Alerting is over budget"}},
+	}
+	for _, test := range tests {
+		notified = nil
+
+		alerts, err := evaluator.Evaluate(testDataset(test.hours, test.budget))
+
+		if err != nil {
+			t.Logf("Expected no error, got %T: %v\n", err, err)
+			t.Fail()
+		}
+
+		if !reflect.DeepEqual(test.expected, notified) {
+			t.Logf("Expected alerts %v for %v of %g hours, got %v\n", test.expected, test.hours, test.budget, notified)
+			t.Fail()
+		}
+
+		if len(alerts) != len(test.expected) {
+			t.Logf("Expected %d delivered alerts, got %d\n", len(test.expected), len(alerts))
+			t.Fail()
+		}
+	}
+}
+
+func TestEvaluatorEvaluateNotifyError(t *testing.T) {
+	state := NewMemoryState()
+	evaluator := &Evaluator{
+		Tracker: &reporting.BudgetTracker{},
+		State:   state,
+		Notifier: NotifierFunc(func(alert *Alert) error {
+			return fmt.Errorf("unreachable")
+		}),
+	}
+
+	alerts, err := evaluator.Evaluate(testDataset(12*harvest.Hour, 10))
+
+	if _, ok := err.(*NotifyError); !ok {
+		t.Logf("Expected error to be a *NotifyError, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if len(alerts) != 0 {
+		t.Logf("Expected no delivered alerts, got %d\n", len(alerts))
+		t.Fail()
+	}
+
+	if fired, _ := state.Fired(alertKey(1, 100)); fired {
+		t.Logf("Expected undelivered alert not to be marked as fired\n")
+		t.Fail()
+	}
+}
@@ -0,0 +1,110 @@
+package alerting
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPNotifier delivers alerts by mail
+type SMTPNotifier struct {
+	// Addr is the address of the mail server, e.g. "mail.example.com:25"
+	Addr string
+	// Auth is used to authenticate at the mail server, if set
+	Auth smtp.Auth
+	From string
+	To   []string
+}
+
+// Notify sends the alert as plain text mail. The subject is encoded as
+// defined by RFC 2047, line breaks are removed from all header values.
+func (s *SMTPNotifier) Notify(alert *Alert) error {
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", headerValue(s.From))
+	fmt.Fprintf(&message, "To: %s\r\n", headerValue(strings.Join(s.To, ", ")))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", headerValue(alert.Subject())))
+	fmt.Fprintf(&message, "Date: %s\r\n", alert.FiredAt.Format(time.RFC1123Z))
+	fmt.Fprintf(&message, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	message.WriteString(strings.Replace(alert.Message(), "\n", "\r\n", -1))
+	return smtp.SendMail(s.Addr, s.Auth, s.From, s.To, message.Bytes())
+}
+
+// headerValue replaces line breaks in value by spaces, so that it can't
+// inject further headers
+func headerValue(value string) string {
+	return strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(value)
+}
+
+// defaultWebhookClient is used by WebhookNotifiers without a Client, so that
+// an unresponsive webhook can't block the evaluation
+var defaultWebhookClient = &http.Client{Timeout: 30 * time.Second}
+
+// WebhookNotifier delivers alerts as JSON via HTTP POST to URL. Any response
+// status other than 2xx is an error.
+type WebhookNotifier struct {
+	URL string
+	// Client is used to send the requests, defaults to a client with a
+	// timeout of 30 seconds
+	Client *http.Client
+	// Header is added to every request, e.g. for authentication
+	Header http.Header
+}
+
+// WebhookPayload is the JSON body sent by the WebhookNotifier
+type WebhookPayload struct {
+	ProjectId   int       `json:"project_id"`
+	ProjectName string    `json:"project_name"`
+	Threshold   float64   `json:"threshold"`
+	Consumed    float64   `json:"consumed"`
+	Subject     string    `json:"subject"`
+	Message     string    `json:"message"`
+	FiredAt     time.Time `json:"fired_at"`
+	ExhaustedOn string    `json:"exhausted_on,omitempty"`
+}
+
+func (w *WebhookNotifier) Notify(alert *Alert) error {
+	payload := WebhookPayload{
+		ProjectId:   alert.Project.ID,
+		ProjectName: alert.Project.Name,
+		Threshold:   alert.Threshold,
+		Consumed:    alert.Consumed,
+		Subject:     alert.Subject(),
+		Message:     alert.Message(),
+		FiredAt:     alert.FiredAt,
+	}
+	if !alert.Budget.ExhaustedOn.IsZero() {
+		payload.ExhaustedOn = alert.Budget.ExhaustedOn.String()
+	}
+	body, err := json.Marshal(&payload)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest("POST", w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, values := range w.Header {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+	request.Header.Set("Content-Type", "application/json")
+	client := w.Client
+	if client == nil {
+		client = defaultWebhookClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("Webhook responded with %s", response.Status)
+	}
+	return nil
+}
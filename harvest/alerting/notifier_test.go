@@ -0,0 +1,176 @@
+package alerting
+
+import (
+	"encoding/json"
+	"mime"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mitch000001/go-harvest/harvest"
+	"github.com/mitch000001/go-harvest/harvest/reporting"
+)
+
+func testAlert() *Alert {
+	project := &harvest.Project{ID: 1, Name: "Alerting", BudgetBy: harvest.BudgetByProject}
+	return &Alert{
+		Project:   project,
+		Budget:    reporting.ProjectBudget{Project: project},
+		Threshold: 80,
+		Consumed:  85,
+		FiredAt:   time.Date(2015, 1, 18, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWebhookNotifierNotify(t *testing.T) {
+	var payload WebhookPayload
+	var token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("X-Token")
+		json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer server.Close()
+
+	notifier := &WebhookNotifier{URL: server.URL, Header: http.Header{"X-Token": []string{"secret"}}}
+
+	err := notifier.Notify(testAlert())
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if payload.ProjectId != 1 || payload.Threshold != 80 || payload.Subject != "Project Alerting has reached 80% of its budget" {
+		t.Logf("Expected payload of alert, got %+v\n", payload)
+		t.Fail()
+	}
+
+	if token != "secret" {
+		t.Logf("Expected header to be sent, got %q\n", token)
+		t.Fail()
+	}
+
+	// Error response
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier = &WebhookNotifier{URL: server.URL}
+
+	err = notifier.Notify(testAlert())
+
+	if err == nil {
+		t.Logf("Expected error for status 500, got nil\n")
+		t.Fail()
+	}
+}
+
+// serveSMTP accepts a single mail and sends its data to mails
+func serveSMTP(listener net.Listener, mails chan<- string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		switch command := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); command {
+		case "EHLO", "HELO", "MAIL", "RCPT":
+			text.PrintfLine("250 OK")
+		case "DATA":
+			text.PrintfLine("354 Go ahead")
+			lines, _ := text.ReadDotLines()
+			mails <- strings.Join(lines, "\n")
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 Bye")
+			return
+		default:
+			text.PrintfLine("502 Not implemented")
+		}
+	}
+}
+
+func TestSMTPNotifierNotify(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("Can't listen on localhost: %v", err)
+	}
+	defer listener.Close()
+	mails := make(chan string, 1)
+	go serveSMTP(listener, mails)
+
+	notifier := &SMTPNotifier{
+		Addr: listener.Addr().String(),
+		From: "harvest@example.com",
+		To:   []string{"pm@example.com"},
+	}
+
+	err = notifier.Notify(testAlert())
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	mail := <-mails
+
+	if !strings.Contains(mail, "Subject: Project Alerting has reached 80% of its budget") {
+		t.Logf("Expected mail to contain the subject, got\n%s\n", mail)
+		t.Fail()
+	}
+
+	if !strings.Contains(mail, "Consumed: 85.0%") {
+		t.Logf("Expected mail to contain the message, got\n%s\n", mail)
+		t.Fail()
+	}
+}
+
+func TestSMTPNotifierNotifyHeaders(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("Can't listen on localhost: %v", err)
+	}
+	defer listener.Close()
+	mails := make(chan string, 1)
+	go serveSMTP(listener, mails)
+
+	notifier := &SMTPNotifier{
+		Addr: listener.Addr().String(),
+		From: "harvest@example.com",
+		To:   []string{"pm@example.com"},
+	}
+	alert := testAlert()
+	alert.Project.Name = "Café\r\nBcc: evil@example.com"
+
+	err = notifier.Notify(alert)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	mail := <-mails
+	header := strings.SplitN(mail, "\n\n", 2)[0]
+
+	if strings.Contains(header, "\nBcc:") {
+		t.Logf("Expected no injected header, got\n%s\n", header)
+		t.Fail()
+	}
+
+	expected := "Subject: " + mime.QEncoding.Encode("utf-8", "Project Café Bcc: evil@example.com has reached 80% of its budget")
+	if !strings.Contains(header, expected) {
+		t.Logf("Expected header to contain %q, got\n%s\n", expected, header)
+		t.Fail()
+	}
+}
@@ -0,0 +1,166 @@
+package alerting
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// State remembers which alerts have fired and the budgets of the projects
+// they have fired for
+type State interface {
+	Fired(key string) (bool, error)
+	MarkFired(key string, at time.Time) error
+	Reset(key string) error
+	// Budget returns the budget of the project recorded by SetBudget or an
+	// empty string
+	Budget(projectId int) (string, error)
+	SetBudget(projectId int, budget string) error
+}
+
+// MemoryState is a State kept in memory only
+type MemoryState struct {
+	mu      sync.Mutex
+	fired   map[string]time.Time
+	budgets map[int]string
+}
+
+func NewMemoryState() *MemoryState {
+	return &MemoryState{fired: make(map[string]time.Time), budgets: make(map[int]string)}
+}
+
+func (m *MemoryState) Fired(key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.fired[key]
+	return ok, nil
+}
+
+func (m *MemoryState) MarkFired(key string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fired[key] = at
+	return nil
+}
+
+func (m *MemoryState) Reset(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.fired, key)
+	return nil
+}
+
+func (m *MemoryState) Budget(projectId int) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.budgets[projectId], nil
+}
+
+func (m *MemoryState) SetBudget(projectId int, budget string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.budgets[projectId] = budget
+	return nil
+}
+
+// FileState is a State persisted as JSON file. Every change is written to the
+// file immediately.
+type FileState struct {
+	path string
+	mu   sync.Mutex
+	data fileStateData
+}
+
+// fileStateData is the JSON content of a FileState
+type fileStateData struct {
+	Alerts  map[string]time.Time `json:"alerts"`
+	Budgets map[int]string       `json:"budgets"`
+}
+
+// NewFileState returns a FileState persisted at path. If the file exists, the
+// fired alerts and budgets are read from it.
+func NewFileState(path string) (*FileState, error) {
+	state := &FileState{path: path}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &state.data); err != nil {
+			return nil, err
+		}
+	}
+	if state.data.Alerts == nil {
+		state.data.Alerts = make(map[string]time.Time)
+	}
+	if state.data.Budgets == nil {
+		state.data.Budgets = make(map[int]string)
+	}
+	return state, nil
+}
+
+func (f *FileState) Fired(key string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.data.Alerts[key]
+	return ok, nil
+}
+
+// FiredAt returns the time the alert with key has fired
+func (f *FileState) FiredAt(key string) (time.Time, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	at, ok := f.data.Alerts[key]
+	return at, ok
+}
+
+func (f *FileState) MarkFired(key string, at time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data.Alerts[key] = at
+	return f.save()
+}
+
+func (f *FileState) Reset(key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.data.Alerts, key)
+	return f.save()
+}
+
+func (f *FileState) Budget(projectId int) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.data.Budgets[projectId], nil
+}
+
+func (f *FileState) SetBudget(projectId int, budget string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data.Budgets[projectId] = budget
+	return f.save()
+}
+
+// save writes the state to a temporary file which replaces the state file, so
+// that the file is never left half written
+func (f *FileState) save() error {
+	data, err := json.MarshalIndent(&f.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
@@ -0,0 +1,47 @@
+package alerting
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "alerts.json")
+	firedAt := time.Date(2015, 1, 18, 12, 0, 0, 0, time.UTC)
+
+	state, err := NewFileState(path)
+
+	if err != nil {
+		t.Logf("Expected no error for missing file, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	state.MarkFired("project/1/80", firedAt)
+	state.MarkFired("project/1/100", firedAt)
+	state.Reset("project/1/100")
+	state.SetBudget(1, "10.00 hours")
+
+	// Reload from disk
+	state, err = NewFileState(path)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	if at, ok := state.FiredAt("project/1/80"); !ok || !at.Equal(firedAt) {
+		t.Logf("Expected alert to be fired at %v, got %v\n", firedAt, at)
+		t.Fail()
+	}
+
+	if fired, _ := state.Fired("project/1/100"); fired {
+		t.Logf("Expected reset alert not to be fired\n")
+		t.Fail()
+	}
+
+	if budget, _ := state.Budget(1); budget != "10.00 hours" {
+		t.Logf("Expected budget %q, got %q\n", "10.00 hours", budget)
+		t.Fail()
+	}
+}
@@ -367,3 +367,13 @@ func (t *testApi) Delete(data CrudModel) error {
 func (t *testApi) Toggle(data ActiveTogglerCrudModel) error {
 	return t.toggleFn(data)
 }
+
+type testCrudEndpointProvider struct {
+	path     string
+	endpoint CrudEndpoint
+}
+
+func (t *testCrudEndpointProvider) CrudEndpoint(path string) CrudEndpoint {
+	t.path = path
+	return t.endpoint
+}
@@ -0,0 +1,78 @@
+package harvest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// ApprovalService approves and rejects timesheets of accounts with the
+// approval feature enabled.
+type ApprovalService struct {
+	provider  CrudEndpointProvider
+	processor RequestProcessor
+}
+
+func NewApprovalService(provider CrudEndpointProvider, processor RequestProcessor) *ApprovalService {
+	return &ApprovalService{provider: provider, processor: processor}
+}
+
+// Submitted populates timesheets with all weeks the given user submitted for
+// approval.
+func (a *ApprovalService) Submitted(user *User, timesheets *[]*Timesheet, params url.Values) error {
+	path := fmt.Sprintf("people/%d/timesheets/submitted", user.Id())
+	return a.provider.CrudEndpoint(path).All(timesheets, params)
+}
+
+// Approve approves the week of the given user starting at weekOf.
+//
+// It returns the ids of all DayEntries which were approved.
+func (a *ApprovalService) Approve(user *User, weekOf ShortDate) ([]int, error) {
+	return a.process(user, weekOf, "approve", nil)
+}
+
+// Reject rejects the week of the given user starting at weekOf. The message
+// is sent to the user and may be empty.
+//
+// It returns the ids of all DayEntries which were rejected.
+func (a *ApprovalService) Reject(user *User, weekOf ShortDate, message string) ([]int, error) {
+	marshaledMessage, err := json.Marshal(map[string]string{"message": message})
+	if err != nil {
+		return nil, err
+	}
+	return a.process(user, weekOf, "reject", bytes.NewReader(marshaledMessage))
+}
+
+// Unsubmit withdraws the submission of the week of the given user starting
+// at weekOf, so that the user can edit the day entries again.
+//
+// It returns the ids of all DayEntries which were unsubmitted.
+func (a *ApprovalService) Unsubmit(user *User, weekOf ShortDate) ([]int, error) {
+	return a.process(user, weekOf, "unsubmit", nil)
+}
+
+func (a *ApprovalService) process(user *User, weekOf ShortDate, action string, body io.Reader) ([]int, error) {
+	path := fmt.Sprintf("people/%d/timesheets/%s/%s", user.Id(), weekOf.Format("2006-01-02"), action)
+	response, err := a.processor.Process("POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	responseBytes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	var payload TimesheetActionPayload
+	err = json.Unmarshal(responseBytes, &payload)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, &ResponseError{&payload.ErrorPayload}
+	}
+	return payload.DayEntryIds, nil
+}
@@ -0,0 +1,162 @@
+package harvest
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApprovalServiceSubmitted(t *testing.T) {
+	called := false
+	expectedParams := url.Values{"foo": []string{"bar"}}
+	testData := apiWrapperTestData{
+		expectedParams:       expectedParams,
+		expectedDataType:     reflect.TypeOf(&[]*Timesheet{}),
+		expectedErrorMessage: "ERR",
+	}
+	provider := &testCrudEndpointProvider{endpoint: testApiAllWrapper(&testData, &called)}
+	service := NewApprovalService(provider, nil)
+
+	err := service.Submitted(&User{ID: 4}, &[]*Timesheet{}, expectedParams)
+
+	if !called {
+		t.Logf("Expected Api.All method to have been called, was not.\n")
+		t.Fail()
+	}
+
+	if errors := testData.getErrors(); errors != "" {
+		t.Logf("Found errors:\n%s", errors)
+		t.Fail()
+	}
+
+	expectedPath := "people/4/timesheets/submitted"
+	if provider.path != expectedPath {
+		t.Logf("Expected path to equal %q, got %q\n", expectedPath, provider.path)
+		t.Fail()
+	}
+
+	if err == nil || err.Error() != "ERR" {
+		t.Logf("Expected error with message 'ERR', got %v\n", err)
+		t.Fail()
+	}
+}
+
+func TestApprovalServiceActions(t *testing.T) {
+	user := &User{ID: 4}
+	weekOf := Date(2015, 2, 9)
+
+	var tests = []struct {
+		action       string
+		fn           func(*ApprovalService) ([]int, error)
+		expectedBody string
+	}{
+		{
+			action: "approve",
+			fn: func(s *ApprovalService) ([]int, error) {
+				return s.Approve(user, weekOf)
+			},
+		},
+		{
+			action: "reject",
+			fn: func(s *ApprovalService) ([]int, error) {
+				return s.Reject(user, weekOf, "Missing notes")
+			},
+			expectedBody: `{"message":"Missing notes"}`,
+		},
+		{
+			action: "unsubmit",
+			fn: func(s *ApprovalService) ([]int, error) {
+				return s.Unsubmit(user, weekOf)
+			},
+		},
+	}
+	for _, test := range tests {
+		processor := &testProcessor{
+			response: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader(`{"day-entry-ids":[1,2,3]}`)),
+			},
+		}
+		service := NewApprovalService(nil, processor)
+
+		ids, err := test.fn(service)
+
+		if err != nil {
+			t.Logf("%s: Expected no error, got %T: %v\n", test.action, err, err)
+			t.Fail()
+		}
+
+		if processor.method != "POST" {
+			t.Logf("%s: Expected request method to equal 'POST', got %q\n", test.action, processor.method)
+			t.Fail()
+		}
+
+		expectedPath := fmt.Sprintf("people/4/timesheets/2015-02-09/%s", test.action)
+		if processor.path != expectedPath {
+			t.Logf("%s: Expected path to equal %q, got %q\n", test.action, expectedPath, processor.path)
+			t.Fail()
+		}
+
+		if test.expectedBody == "" && processor.body != nil {
+			t.Logf("%s: Expected request body to be nil, got '%+#v'\n", test.action, processor.body)
+			t.Fail()
+		}
+
+		if test.expectedBody != "" {
+			var body bytes.Buffer
+			if processor.body != nil {
+				body.ReadFrom(processor.body)
+			}
+			if body.String() != test.expectedBody {
+				t.Logf("%s: Expected request body to equal %q, got %q\n", test.action, test.expectedBody, body.String())
+				t.Fail()
+			}
+		}
+
+		expectedIds := []int{1, 2, 3}
+		if !reflect.DeepEqual(expectedIds, ids) {
+			t.Logf("%s: Expected ids to equal %v, got %v\n", test.action, expectedIds, ids)
+			t.Fail()
+		}
+	}
+
+	// API error
+	processor := &testProcessor{
+		response: &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"message":"Week not submitted"}`)),
+		},
+	}
+	service := NewApprovalService(nil, processor)
+
+	ids, err := service.Approve(user, weekOf)
+
+	if err == nil {
+		t.Logf("Expected error, got nil\n")
+		t.Fail()
+	} else if err.Error() != "Week not submitted" {
+		t.Logf("Expected error message to equal %q, got %q\n", "Week not submitted", err.Error())
+		t.Fail()
+	}
+
+	if ids != nil {
+		t.Logf("Expected ids to be nil, got %v\n", ids)
+		t.Fail()
+	}
+
+	// HTTP error
+	processor = &testProcessor{err: fmt.Errorf("HTTP DOWN")}
+	service = NewApprovalService(nil, processor)
+
+	_, err = service.Unsubmit(user, weekOf)
+
+	if err == nil || err.Error() != "HTTP DOWN" {
+		t.Logf("Expected error with message 'HTTP DOWN', got %v\n", err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,148 @@
+// Package calendar determines working days and expected working hours from a
+// weekly pattern, public holidays and per user exceptions.
+package calendar
+
+import (
+	"time"
+
+	"github.com/mitch000001/go-harvest/harvest"
+)
+
+// WeeklyPattern holds the expected working hours per weekday, indexed by
+// time.Weekday
+type WeeklyPattern [7]harvest.Hours
+
+// StandardWeek returns a pattern with hoursPerDay from monday to friday.
+//
+// The working days are independent of Company.WeekStartDay, which only
+// defines where weeks are split for display.
+func StandardWeek(hoursPerDay harvest.Hours) WeeklyPattern {
+	var pattern WeeklyPattern
+	for day := time.Monday; day <= time.Friday; day++ {
+		pattern[day] = hoursPerDay
+	}
+	return pattern
+}
+
+// Hours returns the expected hours on weekday
+func (w WeeklyPattern) Hours(weekday time.Weekday) harvest.Hours {
+	return w[weekday]
+}
+
+// Total returns the expected hours of a whole week
+func (w WeeklyPattern) Total() harvest.Hours {
+	var total harvest.Hours
+	for _, hours := range w {
+		total += hours
+	}
+	return total
+}
+
+// Exception overrides the expected hours of a single user on a single day,
+// e.g. for vacations, part time days or working on a holiday
+type Exception struct {
+	Date   harvest.ShortDate
+	Hours  harvest.Hours
+	Reason string
+}
+
+// Calendar answers which days are working days and how many hours are
+// expected. Per user settings take precedence over holidays, holidays over
+// the weekly pattern.
+//
+// A Calendar is not safe for concurrent modification.
+type Calendar struct {
+	pattern      WeeklyPattern
+	holidays     *HolidaySet
+	userPatterns map[int]WeeklyPattern
+	exceptions   map[int]map[harvest.ShortDate]Exception
+}
+
+// New returns a Calendar with the given weekly pattern and no holidays
+func New(pattern WeeklyPattern) *Calendar {
+	return &Calendar{
+		pattern:      pattern,
+		holidays:     NewHolidaySet(),
+		userPatterns: make(map[int]WeeklyPattern),
+		exceptions:   make(map[int]map[harvest.ShortDate]Exception),
+	}
+}
+
+// AddHolidays adds the holidays to the calendar. Holidays are days off for
+// all users.
+func (c *Calendar) AddHolidays(holidays ...Holiday) {
+	c.holidays.Add(holidays...)
+}
+
+// Holiday returns the holiday on date, if any
+func (c *Calendar) Holiday(date harvest.ShortDate) (Holiday, bool) {
+	return c.holidays.Holiday(date)
+}
+
+// SetUserPattern sets a weekly pattern for the user with userId, e.g. for part
+// time employees
+func (c *Calendar) SetUserPattern(userId int, pattern WeeklyPattern) {
+	c.userPatterns[userId] = pattern
+}
+
+// Pattern returns the weekly pattern of the user with userId, the pattern set
+// by SetUserPattern or the pattern of the calendar
+func (c *Calendar) Pattern(userId int) WeeklyPattern {
+	if pattern, ok := c.userPatterns[userId]; ok {
+		return pattern
+	}
+	return c.pattern
+}
+
+// AddExceptions adds exceptions for the user with userId. An exception for a
+// date already having one replaces it.
+func (c *Calendar) AddExceptions(userId int, exceptions ...Exception) {
+	userExceptions, ok := c.exceptions[userId]
+	if !ok {
+		userExceptions = make(map[harvest.ShortDate]Exception)
+		c.exceptions[userId] = userExceptions
+	}
+	for _, exception := range exceptions {
+		userExceptions[exception.Date] = exception
+	}
+}
+
+// ExpectedHours returns the hours the user with userId is expected to work on
+// date. Use a userId of 0 for the account wide expectation.
+func (c *Calendar) ExpectedHours(userId int, date harvest.ShortDate) harvest.Hours {
+	if exception, ok := c.exceptions[userId][date]; ok {
+		return exception.Hours
+	}
+	if _, ok := c.holidays.Holiday(date); ok {
+		return 0
+	}
+	return c.Pattern(userId).Hours(date.Weekday())
+}
+
+// IsWorkingDay returns true if the user with userId is expected to work on
+// date
+func (c *Calendar) IsWorkingDay(userId int, date harvest.ShortDate) bool {
+	return c.ExpectedHours(userId, date) > 0
+}
+
+// WorkingDays returns all working days of the user with userId within the
+// timeframe
+func (c *Calendar) WorkingDays(userId int, timeframe harvest.Timeframe) []harvest.ShortDate {
+	var workingDays []harvest.ShortDate
+	for _, day := range timeframe.Days() {
+		if c.IsWorkingDay(userId, day) {
+			workingDays = append(workingDays, day)
+		}
+	}
+	return workingDays
+}
+
+// ExpectedHoursIn returns the sum of hours the user with userId is expected
+// to work within the timeframe
+func (c *Calendar) ExpectedHoursIn(userId int, timeframe harvest.Timeframe) harvest.Hours {
+	var hours harvest.Hours
+	for _, day := range timeframe.Days() {
+		hours += c.ExpectedHours(userId, day)
+	}
+	return hours
+}
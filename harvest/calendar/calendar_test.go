@@ -0,0 +1,176 @@
+package calendar
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mitch000001/go-harvest/harvest"
+)
+
+func TestStandardWeek(t *testing.T) {
+	pattern := StandardWeek(8 * harvest.Hour)
+
+	expected := WeeklyPattern{0, 8 * harvest.Hour, 8 * harvest.Hour, 8 * harvest.Hour, 8 * harvest.Hour, 8 * harvest.Hour, 0}
+
+	if pattern != expected {
+		t.Logf("Expected pattern to equal %v, got %v\n", expected, pattern)
+		t.Fail()
+	}
+
+	if pattern.Total() != 40*harvest.Hour {
+		t.Logf("Expected 40 hours per week, got %v\n", pattern.Total())
+		t.Fail()
+	}
+}
+
+func TestCalendarExpectedHours(t *testing.T) {
+	calendar := New(StandardWeek(8 * harvest.Hour))
+	calendar.AddHolidays(Holiday{Date: harvest.Date(2015, 1, 1), Name: "New Year's Day"})
+	calendar.SetUserPattern(2, WeeklyPattern{0, 4 * harvest.Hour, 4 * harvest.Hour, 4 * harvest.Hour, 0, 0, 0})
+	calendar.AddExceptions(1,
+		Exception{Date: harvest.Date(2015, 1, 5), Hours: 0, Reason: "Vacation"},
+		Exception{Date: harvest.Date(2015, 1, 1), Hours: 6 * harvest.Hour, Reason: "On call"},
+	)
+
+	var tests = []struct {
+		userId   int
+		date     harvest.ShortDate
+		expected harvest.Hours
+	}{
+		// 2015-01-01 is a thursday
+		{0, harvest.Date(2015, 1, 1), 0},
+		{0, harvest.Date(2015, 1, 2), 8 * harvest.Hour},
+		{0, harvest.Date(2015, 1, 3), 0},
+		{1, harvest.Date(2015, 1, 1), 6 * harvest.Hour},
+		{1, harvest.Date(2015, 1, 5), 0},
+		{1, harvest.Date(2015, 1, 6), 8 * harvest.Hour},
+		{2, harvest.Date(2015, 1, 5), 4 * harvest.Hour},
+		{2, harvest.Date(2015, 1, 8), 0},
+	}
+	for _, test := range tests {
+		hours := calendar.ExpectedHours(test.userId, test.date)
+
+		if hours != test.expected {
+			t.Logf("Expected %v hours for user %d on %s, got %v\n", test.expected, test.userId, test.date, hours)
+			t.Fail()
+		}
+	}
+
+	if total := calendar.Pattern(2).Total(); total != 12*harvest.Hour {
+		t.Logf("Expected 12 hours per week for user 2, got %v\n", total)
+		t.Fail()
+	}
+
+	january := harvest.TimeframeForMonth(2015, 1)
+
+	workingDays := calendar.WorkingDays(0, january)
+
+	// 22 weekdays minus new year
+	if len(workingDays) != 21 {
+		t.Logf("Expected 21 working days in january, got %d\n", len(workingDays))
+		t.Fail()
+	}
+
+	expectedHours := calendar.ExpectedHoursIn(1, january)
+
+	// 21 days of 8 hours minus vacation plus on call
+	if expectedHours != (21*8-8+6)*harvest.Hour {
+		t.Logf("Expected %v hours in january, got %v\n", (21*8-8+6)*harvest.Hour, expectedHours)
+		t.Fail()
+	}
+}
+
+func TestLoadHolidays(t *testing.T) {
+	var tests = []struct {
+		path     string
+		expected []Holiday
+	}{
+		{
+			"testdata/holidays.ics",
+			[]Holiday{
+				{harvest.Date(2015, 1, 1), "New Year, Day"},
+				{harvest.Date(2015, 12, 24), "Christmas Holidays"},
+				{harvest.Date(2015, 12, 25), "Christmas Holidays"},
+				{harvest.Date(2015, 12, 26), "Christmas Holidays"},
+			},
+		},
+		{
+			"testdata/holidays.yml",
+			[]Holiday{
+				{harvest.Date(2015, 1, 1), "New Year's Day"},
+				{harvest.Date(2015, 12, 25), "Christmas Day"},
+			},
+		},
+		{
+			"testdata/holidays.csv",
+			[]Holiday{
+				{harvest.Date(2015, 1, 1), "New Year's Day"},
+				{harvest.Date(2015, 12, 25), "Christmas Day"},
+				{harvest.Date(2015, 12, 26), ""},
+			},
+		},
+	}
+	for _, test := range tests {
+		holidays, err := LoadHolidays(test.path)
+
+		if err != nil {
+			t.Logf("Expected no error for %s, got %T: %v\n", test.path, err, err)
+			t.Fail()
+			continue
+		}
+
+		if !reflect.DeepEqual(test.expected, holidays) {
+			t.Logf("Expected holidays of %s to equal %+v, got %+v\n", test.path, test.expected, holidays)
+			t.Fail()
+		}
+	}
+
+	_, err := LoadHolidays("testdata/holidays.txt")
+
+	if err == nil {
+		t.Logf("Expected error for unsupported format, got nil\n")
+		t.Fail()
+	}
+}
+
+func TestParseYAMLList(t *testing.T) {
+	holidays, err := ParseYAML(strings.NewReader("---\n- 2015-01-01\n- 2015-05-01\n"))
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if len(holidays) != 2 || holidays[1].Date != harvest.Date(2015, 5, 1) {
+		t.Logf("Expected two holidays, got %+v\n", holidays)
+		t.Fail()
+	}
+
+	_, err = ParseYAML(strings.NewReader("2015-13-01: Nope\n"))
+
+	if err == nil {
+		t.Logf("Expected error for malformed date, got nil\n")
+		t.Fail()
+	}
+}
+
+func TestHolidaySetAll(t *testing.T) {
+	set := NewHolidaySet(
+		Holiday{Date: harvest.Date(2015, 12, 25)},
+		Holiday{Date: harvest.Date(2015, 1, 1)},
+		Holiday{Date: harvest.Date(2015, 1, 1), Name: "New Year's Day"},
+	)
+
+	holidays := set.All()
+
+	expected := []Holiday{
+		{harvest.Date(2015, 1, 1), "New Year's Day"},
+		{harvest.Date(2015, 12, 25), ""},
+	}
+
+	if !reflect.DeepEqual(expected, holidays) {
+		t.Logf("Expected holidays to equal %+v, got %+v\n", expected, holidays)
+		t.Fail()
+	}
+}
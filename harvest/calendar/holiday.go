@@ -0,0 +1,259 @@
+package calendar
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mitch000001/go-harvest/harvest"
+)
+
+// Holiday is a public holiday, a day off for all users
+type Holiday struct {
+	Date harvest.ShortDate
+	Name string
+}
+
+// HolidaySet is a set of holidays indexed by date
+type HolidaySet struct {
+	holidays map[harvest.ShortDate]Holiday
+}
+
+func NewHolidaySet(holidays ...Holiday) *HolidaySet {
+	set := &HolidaySet{holidays: make(map[harvest.ShortDate]Holiday)}
+	set.Add(holidays...)
+	return set
+}
+
+// Add adds the holidays to the set. A holiday on a date already within the
+// set replaces it.
+func (h *HolidaySet) Add(holidays ...Holiday) {
+	for _, holiday := range holidays {
+		h.holidays[holiday.Date] = holiday
+	}
+}
+
+// Holiday returns the holiday on date, if any
+func (h *HolidaySet) Holiday(date harvest.ShortDate) (Holiday, bool) {
+	holiday, ok := h.holidays[date]
+	return holiday, ok
+}
+
+// All returns all holidays sorted by date
+func (h *HolidaySet) All() []Holiday {
+	holidays := make([]Holiday, 0, len(h.holidays))
+	for _, holiday := range h.holidays {
+		holidays = append(holidays, holiday)
+	}
+	sort.Slice(holidays, func(i, j int) bool {
+		return holidays[i].Date.Before(holidays[j].Date)
+	})
+	return holidays
+}
+
+// LoadHolidays reads the holidays from the file at path. The format is chosen
+// by the file extension: .ics or .ical for iCalendar, .yml or .yaml for YAML
+// and .csv for CSV. See ParseICal, ParseYAML and ParseCSV.
+func LoadHolidays(path string) ([]Holiday, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ics", ".ical":
+		return ParseICal(file)
+	case ".yml", ".yaml":
+		return ParseYAML(file)
+	case ".csv":
+		return ParseCSV(file)
+	}
+	return nil, fmt.Errorf("Unsupported holiday file format: %s", path)
+}
+
+// ParseCSV parses holidays from CSV records of the form
+//
+//	2015-01-01,New Year's Day
+//
+// The name is optional. A header line starting with "date" is skipped.
+func ParseCSV(r io.Reader) ([]Holiday, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	var holidays []Holiday
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return holidays, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "date") {
+			continue
+		}
+		holiday, err := parseHoliday(record[0], record[1:]...)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		holidays = append(holidays, holiday)
+	}
+}
+
+// ParseYAML parses holidays from a simple YAML document, which is either a
+// mapping from dates to names or a list of dates:
+//
+//	holidays:
+//	  2015-01-01: New Year's Day
+//	  2015-12-25: Christmas Day
+//
+//	- 2015-01-01
+//	- 2015-12-25
+//
+// Only this subset of YAML is supported. The top level key is optional and
+// ignored.
+func ParseYAML(r io.Reader) ([]Holiday, error) {
+	scanner := bufio.NewScanner(r)
+	var holidays []Holiday
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || text == "---" {
+			continue
+		}
+		text = strings.TrimSpace(strings.TrimPrefix(text, "- "))
+		date, name := text, ""
+		if i := strings.Index(text, ":"); i != -1 {
+			date, name = strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:])
+		}
+		if name == "" && !isDigit(date) {
+			// a top level key like "holidays:"
+			continue
+		}
+		holiday, err := parseHoliday(date, unquoteYAML(name))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		holidays = append(holidays, holiday)
+	}
+	return holidays, scanner.Err()
+}
+
+func isDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+func unquoteYAML(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// ParseICal parses all-day events of an iCalendar file as holidays. Events
+// spanning several days result in a holiday for every day. Recurrence rules
+// are not supported.
+func ParseICal(r io.Reader) ([]Holiday, error) {
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, err
+	}
+	var holidays []Holiday
+	var inEvent bool
+	var name string
+	var start, end harvest.ShortDate
+	for _, line := range lines {
+		property, value := splitICalLine(line)
+		switch {
+		case property == "BEGIN" && value == "VEVENT":
+			inEvent = true
+			name, start, end = "", harvest.ShortDate{}, harvest.ShortDate{}
+		case property == "END" && value == "VEVENT":
+			inEvent = false
+			if start.IsZero() {
+				return nil, fmt.Errorf("Event %q without start date", name)
+			}
+			// DTEND is exclusive
+			last := start
+			if !end.IsZero() && end.After(start) {
+				last = end.AddDays(-1)
+			}
+			for _, day := range (harvest.Timeframe{StartDate: start, EndDate: last}).Days() {
+				holidays = append(holidays, Holiday{Date: day, Name: name})
+			}
+		case !inEvent:
+			continue
+		case property == "SUMMARY":
+			name = unescapeICal(value)
+		case property == "DTSTART":
+			start, err = parseICalDate(value)
+		case property == "DTEND":
+			end, err = parseICalDate(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return holidays, nil
+}
+
+// unfoldICalLines returns the logical lines of an iCalendar file, joining
+// continuation lines starting with a space or tab
+func unfoldICalLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// splitICalLine returns the property name without parameters and the value
+// of a content line like "DTSTART;VALUE=DATE:20150101"
+func splitICalLine(line string) (string, string) {
+	i := strings.Index(line, ":")
+	if i == -1 {
+		return strings.ToUpper(line), ""
+	}
+	property := strings.ToUpper(strings.SplitN(line[:i], ";", 2)[0])
+	return property, line[i+1:]
+}
+
+// parseICalDate parses DATE and DATE-TIME values, using the date only
+func parseICalDate(value string) (harvest.ShortDate, error) {
+	if len(value) < 8 {
+		return harvest.ShortDate{}, fmt.Errorf("Malformed iCalendar date %q", value)
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return harvest.ShortDate{}, fmt.Errorf("Malformed iCalendar date %q", value)
+	}
+	return harvest.NewShortDate(date), nil
+}
+
+func unescapeICal(value string) string {
+	return strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`).Replace(value)
+}
+
+func parseHoliday(date string, name ...string) (Holiday, error) {
+	shortDate, err := harvest.ParseShortDate(strings.TrimSpace(date))
+	if err != nil {
+		return Holiday{}, fmt.Errorf("Malformed holiday date %q", date)
+	}
+	holiday := Holiday{Date: shortDate}
+	if len(name) > 0 {
+		holiday.Name = strings.TrimSpace(name[0])
+	}
+	return holiday, nil
+}
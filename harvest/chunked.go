@@ -0,0 +1,169 @@
+package harvest
+
+import (
+	"fmt"
+	"sort"
+)
+
+const defaultChunkWorkers = 4
+
+// ChunkOptions configure the chunked fetching of entries and expenses
+type ChunkOptions struct {
+	// Period is the size of a chunk, PeriodWeek or PeriodMonth. Any other
+	// value, including the zero value, results in monthly chunks.
+	Period Period
+	// WeekStart is the first day of weekly chunks
+	WeekStart WeekStartDay
+	// Workers is the number of chunks fetched concurrently, defaults to 4
+	Workers int
+	// Project, if set, narrows the timeframe to the dates between the
+	// project's HintEarliestRecordAt and HintLatestRecordAt
+	Project *Project
+}
+
+func (c ChunkOptions) workers() int {
+	if c.Workers <= 0 {
+		return defaultChunkWorkers
+	}
+	return c.Workers
+}
+
+// chunks splits timeframe into the chunks to fetch
+func (c ChunkOptions) chunks(timeframe Timeframe) []Timeframe {
+	if c.Project != nil {
+		hints := timeframe
+		if !c.Project.HintEarliestRecordAt.IsZero() {
+			hints.StartDate = c.Project.HintEarliestRecordAt
+		}
+		if !c.Project.HintLatestRecordAt.IsZero() {
+			hints.EndDate = c.Project.HintLatestRecordAt
+		}
+		var ok bool
+		timeframe, ok = timeframe.Intersect(hints)
+		if !ok {
+			return nil
+		}
+	}
+	period := PeriodMonth
+	if c.Period == PeriodWeek {
+		period = PeriodWeek
+	}
+	return timeframe.Split(period, c.WeekStart)
+}
+
+// ChunkError is returned if a chunk could not be fetched
+type ChunkError struct {
+	Timeframe Timeframe
+	Err       error
+}
+
+func (c *ChunkError) Error() string {
+	return fmt.Sprintf("Error fetching chunk %s: %v", c.Timeframe, c.Err)
+}
+
+// Stream fetches the entries matching query in chunks of the query's
+// Timeframe and calls fn for every entry, ordered by date and ID. Chunks are
+// fetched concurrently, at most as many chunks as workers are held in memory.
+//
+// Stream stops and returns the error if a chunk can't be fetched, as a
+// *ChunkError, or if fn returns an error.
+func (d *DayEntryService) Stream(query DayEntryQuery, options ChunkOptions, fn func(*DayEntry) error) error {
+	if _, err := query.values(d.scope); err != nil {
+		return err
+	}
+	fetch := func(chunk Timeframe) ([]*DayEntry, error) {
+		chunkQuery := query
+		chunkQuery.Timeframe = chunk
+		var entries []*DayEntry
+		err := d.Query(&entries, chunkQuery)
+		sort.Slice(entries, func(i, j int) bool {
+			a, b := entries[i], entries[j]
+			if !a.SpentAt.Equal(b.SpentAt) {
+				return a.SpentAt.Before(b.SpentAt)
+			}
+			return a.ID < b.ID
+		})
+		return entries, err
+	}
+	return streamChunks(options.chunks(query.Timeframe), options.workers(), fetch, fn)
+}
+
+// Stream fetches the expenses matching query in chunks of the query's
+// Timeframe and calls fn for every expense, ordered by date and ID. See
+// DayEntryService.Stream.
+func (e *ExpenseService) Stream(query ExpenseQuery, options ChunkOptions, fn func(*Expense) error) error {
+	if _, err := query.Values(); err != nil {
+		return err
+	}
+	fetch := func(chunk Timeframe) ([]*Expense, error) {
+		chunkQuery := query
+		chunkQuery.Timeframe = chunk
+		var expenses []*Expense
+		err := e.Query(&expenses, chunkQuery)
+		sort.Slice(expenses, func(i, j int) bool {
+			a, b := expenses[i], expenses[j]
+			if !a.SpentAt.Equal(b.SpentAt) {
+				return a.SpentAt.Before(b.SpentAt)
+			}
+			return a.ID < b.ID
+		})
+		return expenses, err
+	}
+	return streamChunks(options.chunks(query.Timeframe), options.workers(), fetch, fn)
+}
+
+type chunkResult[T any] struct {
+	items []T
+	err   error
+}
+
+// streamChunks fetches the chunks with the given number of workers and calls
+// fn for the items of every chunk in the order of chunks
+func streamChunks[T any](chunks []Timeframe, workers int, fetch func(Timeframe) ([]T, error), fn func(T) error) error {
+	results := make([]chan chunkResult[T], len(chunks))
+	for i := range results {
+		results[i] = make(chan chunkResult[T], 1)
+	}
+	done := make(chan struct{})
+	defer close(done)
+	// a token is taken for every chunk dispatched and returned when the chunk
+	// is consumed, which bounds the number of fetched chunks held in memory
+	tokens := make(chan struct{}, workers)
+	indices := make(chan int)
+	go func() {
+		defer close(indices)
+		for i := range chunks {
+			select {
+			case tokens <- struct{}{}:
+			case <-done:
+				return
+			}
+			select {
+			case indices <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range indices {
+				items, err := fetch(chunks[i])
+				results[i] <- chunkResult[T]{items, err}
+			}
+		}()
+	}
+	for i, chunk := range chunks {
+		result := <-results[i]
+		if result.err != nil {
+			return &ChunkError{Timeframe: chunk, Err: result.err}
+		}
+		for _, item := range result.items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		<-tokens
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package harvest
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDayEntryServiceStream(t *testing.T) {
+	var mu sync.Mutex
+	var requested []string
+	endpoint := testApiAll(func(data interface{}, params url.Values) error {
+		timeframe, err := TimeframeFromQuery(params)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		requested = append(requested, timeframe.String())
+		mu.Unlock()
+		// later chunks respond faster to shuffle the completion order
+		time.Sleep(time.Duration(12-int(timeframe.StartDate.Month())) * time.Millisecond)
+		start := timeframe.StartDate
+		*(data.(*[]*DayEntry)) = []*DayEntry{
+			{ID: int(start.Month()) * 10, SpentAt: start.AddDays(1)},
+			{ID: int(start.Month())*10 + 1, SpentAt: start},
+		}
+		return nil
+	})
+	service := NewDayEntryService(endpoint)
+
+	query := DayEntryQuery{Timeframe: NewTimeframe(2015, 1, 1, 2015, 12, 31)}
+	options := ChunkOptions{
+		Period:  PeriodMonth,
+		Workers: 3,
+		Project: &Project{HintEarliestRecordAt: Date(2015, 3, 15), HintLatestRecordAt: Date(2015, 6, 10)},
+	}
+
+	var ids []int
+	err := service.Stream(query, options, func(entry *DayEntry) error {
+		ids = append(ids, entry.ID)
+		return nil
+	})
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	expectedIds := []int{31, 30, 41, 40, 51, 50, 61, 60}
+
+	if !reflect.DeepEqual(expectedIds, ids) {
+		t.Logf("Expected entries to be streamed in order %v, got %v\n", expectedIds, ids)
+		t.Fail()
+	}
+
+	if len(requested) != 4 {
+		t.Logf("Expected 4 chunks to be requested, got %v\n", requested)
+		t.Fail()
+	}
+
+	// Stop streaming
+	ids = nil
+	err = service.Stream(query, ChunkOptions{}, func(entry *DayEntry) error {
+		ids = append(ids, entry.ID)
+		if len(ids) == 3 {
+			return fmt.Errorf("stop")
+		}
+		return nil
+	})
+
+	if err == nil || err.Error() != "stop" {
+		t.Logf("Expected error 'stop', got %v\n", err)
+		t.Fail()
+	}
+
+	if len(ids) != 3 {
+		t.Logf("Expected 3 entries before stopping, got %d\n", len(ids))
+		t.Fail()
+	}
+}
+
+func TestDayEntryServiceStreamError(t *testing.T) {
+	endpoint := testApiAll(func(data interface{}, params url.Values) error {
+		if params.Get("from") == "20150201" {
+			return fmt.Errorf("timeout")
+		}
+		*(data.(*[]*DayEntry)) = []*DayEntry{{ID: 1}}
+		return nil
+	})
+	service := NewDayEntryService(endpoint)
+
+	query := DayEntryQuery{Timeframe: NewTimeframe(2015, 1, 1, 2015, 3, 31)}
+
+	var count int
+	err := service.Stream(query, ChunkOptions{Workers: 1}, func(entry *DayEntry) error {
+		count++
+		return nil
+	})
+
+	chunkError, ok := err.(*ChunkError)
+	if !ok {
+		t.Logf("Expected error to be a *ChunkError, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	if chunkError.Timeframe != NewTimeframe(2015, 2, 1, 2015, 2, 28) {
+		t.Logf("Expected failed chunk to be february, got %s\n", chunkError.Timeframe)
+		t.Fail()
+	}
+
+	if count != 1 {
+		t.Logf("Expected entries of the first chunk to be streamed, got %d\n", count)
+		t.Fail()
+	}
+}
+
+func TestChunkOptionsChunks(t *testing.T) {
+	timeframe := NewTimeframe(2015, 1, 1, 2015, 1, 31)
+
+	chunks := ChunkOptions{Period: PeriodWeek, WeekStart: Monday}.chunks(timeframe)
+
+	if len(chunks) != 5 || chunks[1] != NewTimeframe(2015, 1, 5, 2015, 1, 11) {
+		t.Logf("Expected 5 weekly chunks starting on monday, got %v\n", chunks)
+		t.Fail()
+	}
+
+	project := &Project{HintEarliestRecordAt: Date(2015, 3, 1)}
+
+	chunks = ChunkOptions{Project: project}.chunks(timeframe)
+
+	if len(chunks) != 0 {
+		t.Logf("Expected no chunks outside of the project hints, got %v\n", chunks)
+		t.Fail()
+	}
+}
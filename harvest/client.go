@@ -17,6 +17,8 @@ type Client struct {
 	Details                 string    `json:"details,omitempty"`
 	DefaultInvoiceTimeframe Timeframe `json:"default-invoice-timeframe,omitempty"`
 	LastInvoiceKind         string    `json:"last-invoice-kind,omitempty"`
+	// JSON attributes not modeled by Client
+	Extra ExtraFields `json:"-"`
 }
 
 func (c *Client) Type() string {
@@ -31,6 +33,10 @@ func (c *Client) SetId(id int) {
 	c.ID = id
 }
 
+func (c *Client) ExtraFields() *ExtraFields {
+	return &c.Extra
+}
+
 func (c *Client) ToggleActive() bool {
 	c.Active = !c.Active
 	return c.Active
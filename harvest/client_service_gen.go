@@ -20,26 +20,41 @@ func NewClientService(endpoint CrudTogglerEndpoint) *ClientService {
 	return &service
 }
 
+// Typed returns the typed service for clients
+func (s *ClientService) Typed() *TogglerService[Client, *Client] {
+	return NewTogglerService[Client](s.endpoint)
+}
+
 func (s *ClientService) All(clients *[]*Client, params url.Values) error {
-	return s.endpoint.All(clients, params)
+	all, err := s.Typed().All(params)
+	if all != nil {
+		*clients = all
+	}
+	return err
 }
 
 func (s *ClientService) Find(id int, client *Client, params url.Values) error {
-	return s.endpoint.Find(id, client, params)
+	found, err := s.Typed().Find(id, params)
+	if found != nil {
+		*client = *found
+	}
+	return err
 }
 
 func (s *ClientService) Create(client *Client) error {
-	return s.endpoint.Create(client)
+	return s.Typed().Create(client)
 }
 
-func (s *ClientService) Update(client *Client) error {
-	return s.endpoint.Update(client)
+// Update updates the given client. If fields are provided, only these
+// fields are sent to the API, see NewPatch.
+func (s *ClientService) Update(client *Client, fields ...string) error {
+	return s.Typed().Update(client, fields...)
 }
 
 func (s *ClientService) Delete(client *Client) error {
-	return s.endpoint.Delete(client)
+	return s.Typed().Delete(client)
 }
 
 func (s *ClientService) Toggle(client *Client) error {
-	return s.endpoint.Toggle(client)
+	return s.Typed().Toggle(client)
 }
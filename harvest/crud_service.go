@@ -0,0 +1,96 @@
+package harvest
+
+import "net/url"
+
+// CrudModelPointer is satisfied by pointers to T which implement CrudModel
+type CrudModelPointer[T any] interface {
+	*T
+	CrudModel
+}
+
+// ActiveTogglerCrudModelPointer is satisfied by pointers to T which
+// implement ActiveTogglerCrudModel
+type ActiveTogglerCrudModelPointer[T any] interface {
+	*T
+	ActiveTogglerCrudModel
+}
+
+// Service provides typed CRUD access to the resources of an endpoint.
+//
+// T is the model type, e.g. Project, P the pointer to it. P is inferred by the
+// compiler, so a Service is created with NewService[Project](endpoint).
+type Service[T any, P CrudModelPointer[T]] struct {
+	endpoint CrudEndpoint
+}
+
+func NewService[T any, P CrudModelPointer[T]](endpoint CrudEndpoint) *Service[T, P] {
+	return &Service[T, P]{endpoint: endpoint}
+}
+
+// All returns all resources found at the endpoint
+//
+// If the API responds with unknown fields in strict mode, the resources are
+// returned together with the *UnknownFieldsError.
+func (s *Service[T, P]) All(params url.Values) ([]P, error) {
+	var models []P
+	err := s.endpoint.All(&models, params)
+	if err != nil && !isUnknownFields(err) {
+		return nil, err
+	}
+	return models, err
+}
+
+// Find returns the resource with the given id
+//
+// If the API responds with unknown fields in strict mode, the resource is
+// returned together with the *UnknownFieldsError.
+func (s *Service[T, P]) Find(id int, params url.Values) (P, error) {
+	model := P(new(T))
+	err := s.endpoint.Find(id, model, params)
+	if err != nil && !isUnknownFields(err) {
+		return nil, err
+	}
+	return model, err
+}
+
+// Create creates the model at the API and sets its id
+func (s *Service[T, P]) Create(model P) error {
+	return s.endpoint.Create(model)
+}
+
+// Update updates the given model. If fields are provided, only these fields
+// are sent to the API, see NewPatch.
+func (s *Service[T, P]) Update(model P, fields ...string) error {
+	if len(fields) != 0 {
+		return s.endpoint.Update(NewPatch(model, fields...))
+	}
+	return s.endpoint.Update(model)
+}
+
+func (s *Service[T, P]) Delete(model P) error {
+	return s.endpoint.Delete(model)
+}
+
+// TogglerService is a Service for resources which can be activated and
+// deactivated
+type TogglerService[T any, P ActiveTogglerCrudModelPointer[T]] struct {
+	*Service[T, P]
+	endpoint CrudTogglerEndpoint
+}
+
+func NewTogglerService[T any, P ActiveTogglerCrudModelPointer[T]](endpoint CrudTogglerEndpoint) *TogglerService[T, P] {
+	return &TogglerService[T, P]{
+		Service:  NewService[T, P](endpoint),
+		endpoint: endpoint,
+	}
+}
+
+// Toggle toggles the active state of the given model
+func (s *TogglerService[T, P]) Toggle(model P) error {
+	return s.endpoint.Toggle(model)
+}
+
+func isUnknownFields(err error) bool {
+	_, ok := err.(*UnknownFieldsError)
+	return ok
+}
@@ -0,0 +1,177 @@
+package harvest
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestServiceAll(t *testing.T) {
+	expectedParams := url.Values{"foo": []string{"bar"}}
+	endpoint := testApiAll(func(data interface{}, params url.Values) error {
+		projects, ok := data.(*[]*Project)
+		if !ok {
+			return fmt.Errorf("Unexpected data type %T", data)
+		}
+		if !reflect.DeepEqual(expectedParams, params) {
+			return fmt.Errorf("Unexpected params %v", params)
+		}
+		*projects = []*Project{&Project{ID: 1}, &Project{ID: 2}}
+		return nil
+	})
+	service := NewService[Project](endpoint)
+
+	projects, err := service.All(expectedParams)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	expectedProjects := []*Project{&Project{ID: 1}, &Project{ID: 2}}
+
+	if !reflect.DeepEqual(expectedProjects, projects) {
+		t.Logf("Expected projects to equal %+#v, got %+#v\n", expectedProjects, projects)
+		t.Fail()
+	}
+
+	// Error
+	service = NewService[Project](testApiAll(func(interface{}, url.Values) error {
+		return fmt.Errorf("ERR")
+	}))
+
+	projects, err = service.All(nil)
+
+	if err == nil || err.Error() != "ERR" {
+		t.Logf("Expected error with message 'ERR', got %v\n", err)
+		t.Fail()
+	}
+
+	if projects != nil {
+		t.Logf("Expected projects to be nil, got %+#v\n", projects)
+		t.Fail()
+	}
+
+	// Unknown fields
+	service = NewService[Project](testApiAll(func(data interface{}, params url.Values) error {
+		*(data.(*[]*Project)) = []*Project{&Project{ID: 1}}
+		return &UnknownFieldsError{Type: "Project", Fields: []string{"foo"}}
+	}))
+
+	projects, err = service.All(nil)
+
+	if _, ok := err.(*UnknownFieldsError); !ok {
+		t.Logf("Expected error of type *UnknownFieldsError, got %T\n", err)
+		t.Fail()
+	}
+
+	if len(projects) != 1 {
+		t.Logf("Expected one project, got %d\n", len(projects))
+		t.Fail()
+	}
+}
+
+func TestServiceFind(t *testing.T) {
+	endpoint := testApiFind(func(id interface{}, data interface{}, params url.Values) error {
+		project, ok := data.(*Project)
+		if !ok {
+			return fmt.Errorf("Unexpected data type %T", data)
+		}
+		project.ID = id.(int)
+		return nil
+	})
+	service := NewService[Project](endpoint)
+
+	project, err := service.Find(12, nil)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if project == nil || project.ID != 12 {
+		t.Logf("Expected project with id 12, got %+#v\n", project)
+		t.Fail()
+	}
+
+	// Error
+	service = NewService[Project](testApiFind(func(interface{}, interface{}, url.Values) error {
+		return NewNotFoundError("")
+	}))
+
+	project, err = service.Find(12, nil)
+
+	if !IsNotFound(err) {
+		t.Logf("Expected NotFound error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if project != nil {
+		t.Logf("Expected project to be nil, got %+#v\n", project)
+		t.Fail()
+	}
+}
+
+func TestServiceCreateUpdateDelete(t *testing.T) {
+	var calls []string
+	var models []CrudModel
+	record := func(name string) func(CrudModel) error {
+		return func(model CrudModel) error {
+			calls = append(calls, name)
+			models = append(models, model)
+			return nil
+		}
+	}
+	endpoint := &testApi{
+		createFn: record("Create"),
+		updateFn: record("Update"),
+		deleteFn: record("Delete"),
+	}
+	service := NewService[Client](endpoint)
+	client := &Client{ID: 3}
+
+	service.Create(client)
+	service.Update(client)
+	service.Update(client, "active")
+	service.Delete(client)
+
+	expectedCalls := []string{"Create", "Update", "Update", "Delete"}
+
+	if !reflect.DeepEqual(expectedCalls, calls) {
+		t.Logf("Expected calls to equal %v, got %v\n", expectedCalls, calls)
+		t.FailNow()
+	}
+
+	if models[0] != client || models[1] != client || models[3] != client {
+		t.Logf("Expected client to be passed to the endpoint, got %+#v\n", models)
+		t.Fail()
+	}
+
+	if patch, ok := models[2].(*Patch); !ok || patch.Model() != client {
+		t.Logf("Expected patch for client to be passed to the endpoint, got %+#v\n", models[2])
+		t.Fail()
+	}
+}
+
+func TestTogglerServiceToggle(t *testing.T) {
+	var toggled ActiveTogglerCrudModel
+	endpoint := testApiToggle(func(model ActiveTogglerCrudModel) error {
+		toggled = model
+		return nil
+	})
+	service := NewTogglerService[User](endpoint)
+	user := &User{ID: 3}
+
+	err := service.Toggle(user)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if toggled != user {
+		t.Logf("Expected user to be toggled, got %+#v\n", toggled)
+		t.Fail()
+	}
+}
@@ -3,7 +3,7 @@ package harvest
 import "time"
 
 type DayEntry struct {
-	Hours     float64   `json:"hours"`
+	Hours     Hours     `json:"hours"`
 	ID        int       `json:"id"`
 	Notes     string    `json:"notes"`
 	ProjectId int       `json:"project-id"`
@@ -16,4 +16,10 @@ type DayEntry struct {
 	IsClosed  bool      `json:"is-closed"`
 	UpdatedAt time.Time `json:"updated-at"`
 	CreatedAt time.Time `json:"created-at"`
+	// JSON attributes not modeled by DayEntry
+	Extra ExtraFields `json:"-"`
+}
+
+func (d *DayEntry) ExtraFields() *ExtraFields {
+	return &d.Extra
 }
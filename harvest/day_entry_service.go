@@ -7,6 +7,7 @@ import (
 
 type DayEntryService struct {
 	endpoint AllEndpoint
+	scope    dayEntryScope
 }
 
 func NewDayEntryService(endpoint AllEndpoint) *DayEntryService {
@@ -19,3 +20,14 @@ func (d *DayEntryService) All(dayEntries *[]*DayEntry, params url.Values) error
 	}
 	return d.endpoint.All(dayEntries, params)
 }
+
+// Query populates dayEntries with all entries matching query. It returns a
+// ValidationError if the query contains filters not supported by the
+// endpoint.
+func (d *DayEntryService) Query(dayEntries *[]*DayEntry, query DayEntryQuery) error {
+	params, err := query.values(d.scope)
+	if err != nil {
+		return err
+	}
+	return d.endpoint.All(dayEntries, params)
+}
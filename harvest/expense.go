@@ -8,8 +8,8 @@ type Expense struct {
 	Notes             string    `json:"notes"`
 	ProjectId         int       `json:"project-id"`
 	UserId            int       `json:"user-id"`
-	SpentAt           time.Time `json:"spent-at"`
-	TotalCost         float64   `json:"total-cost"`
+	SpentAt           ShortDate `json:"spent-at"`
+	TotalCost         Money     `json:"total-cost"`
 	Units             float64   `json:"units"`
 	TaskId            int       `json:"task-id"`
 	// was this record invoiced, or marked as invoiced
@@ -18,4 +18,10 @@ type Expense struct {
 	IsClosed  bool      `json:"is-closed"`
 	UpdatedAt time.Time `json:"updated-at"`
 	CreatedAt time.Time `json:"created-at"`
+	// JSON attributes not modeled by Expense
+	Extra ExtraFields `json:"-"`
+}
+
+func (e *Expense) ExtraFields() *ExtraFields {
+	return &e.Extra
 }
@@ -19,3 +19,12 @@ func (e *ExpenseService) All(expenses *[]*Expense, params url.Values) error {
 	}
 	return e.endpoint.All(expenses, params)
 }
+
+// Query populates expenses with all expenses matching query
+func (e *ExpenseService) Query(expenses *[]*Expense, query ExpenseQuery) error {
+	params, err := query.Values()
+	if err != nil {
+		return err
+	}
+	return e.endpoint.All(expenses, params)
+}
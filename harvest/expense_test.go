@@ -0,0 +1,22 @@
+package harvest
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExpenseUnmarshalJSONSpentAt(t *testing.T) {
+	var expense Expense
+
+	err := json.Unmarshal([]byte(`{"id":1,"spent-at":"2015-01-05"}`), &expense)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	if expense.SpentAt != Date(2015, 1, 5) {
+		t.Logf("Expected spent at to equal %s, got %s\n", Date(2015, 1, 5), expense.SpentAt)
+		t.Fail()
+	}
+}
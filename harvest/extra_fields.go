@@ -0,0 +1,135 @@
+package harvest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ExtraFields holds JSON attributes which are not modeled by a struct, keyed
+// by their JSON name.
+type ExtraFields map[string]json.RawMessage
+
+// ExtraFieldsModel is implemented by models which preserve unknown JSON
+// attributes across Find and Update round trips.
+type ExtraFieldsModel interface {
+	ExtraFields() *ExtraFields
+}
+
+type UnknownFieldsError struct {
+	Type   string
+	Fields []string
+}
+
+func (u *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("Unknown fields for %s: %s", u.Type, strings.Join(u.Fields, ", "))
+}
+
+// decodeExtraFields stores all attributes of the JSON object data unknown to
+// model within the models ExtraFields, if the model implements
+// ExtraFieldsModel. Like encoding/json, attribute names are matched case
+// insensitive.
+//
+// It returns the names of all unknown attributes.
+func decodeExtraFields(data []byte, model interface{}) ([]string, error) {
+	modelValue := reflect.ValueOf(model)
+	for modelValue.Kind() == reflect.Ptr && modelValue.Elem().Kind() == reflect.Ptr {
+		modelValue = modelValue.Elem()
+	}
+	if modelValue.Kind() != reflect.Ptr || modelValue.IsNil() || modelValue.Elem().Kind() != reflect.Struct {
+		return nil, nil
+	}
+	modelType := modelValue.Elem().Type()
+	var attributes map[string]json.RawMessage
+	err := json.Unmarshal(data, &attributes)
+	if err != nil {
+		return nil, err
+	}
+	knownFields := make(map[string]bool)
+	for i := 0; i < modelType.NumField(); i++ {
+		if name := jsonFieldName(modelType.Field(i)); name != "" {
+			knownFields[strings.ToLower(name)] = true
+		}
+	}
+	extra := make(ExtraFields)
+	var unknownFields []string
+	for name, value := range attributes {
+		if !knownFields[strings.ToLower(name)] {
+			extra[name] = value
+			unknownFields = append(unknownFields, name)
+		}
+	}
+	sort.Strings(unknownFields)
+	if extraModel, ok := modelValue.Interface().(ExtraFieldsModel); ok {
+		if len(extra) == 0 {
+			extra = nil
+		}
+		*extraModel.ExtraFields() = extra
+	}
+	return unknownFields, nil
+}
+
+// decodeAllExtraFields calls decodeExtraFields for every element of the
+// slice pointed to by data with the corresponding raw payload.
+//
+// It returns the names of all unknown attributes found within any element.
+func decodeAllExtraFields(rawPayloads []*json.RawMessage, data interface{}) ([]string, error) {
+	dataValue := reflect.Indirect(reflect.ValueOf(data))
+	if dataValue.Kind() != reflect.Slice {
+		return nil, nil
+	}
+	found := make(map[string]bool)
+	var unknownFields []string
+	for i := 0; i < dataValue.Len() && i < len(rawPayloads); i++ {
+		if rawPayloads[i] == nil {
+			continue
+		}
+		fields, err := decodeExtraFields(*rawPayloads[i], dataValue.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range fields {
+			if !found[f] {
+				found[f] = true
+				unknownFields = append(unknownFields, f)
+			}
+		}
+	}
+	sort.Strings(unknownFields)
+	return unknownFields, nil
+}
+
+// encodeExtraFields adds the ExtraFields of model to the marshaled JSON
+// object. Attributes already present within marshaledData take precedence.
+func encodeExtraFields(marshaledData []byte, model interface{}) ([]byte, error) {
+	extraModel, ok := model.(ExtraFieldsModel)
+	if !ok || len(*extraModel.ExtraFields()) == 0 {
+		return marshaledData, nil
+	}
+	var attributes map[string]json.RawMessage
+	err := json.Unmarshal(marshaledData, &attributes)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range *extraModel.ExtraFields() {
+		if _, ok := attributes[name]; !ok {
+			attributes[name] = value
+		}
+	}
+	return json.Marshal(attributes)
+}
+
+// typeName returns the name of the type of data, dereferencing pointers and
+// slices
+func typeName(data interface{}) string {
+	dataType := reflect.TypeOf(data)
+	for dataType != nil && (dataType.Kind() == reflect.Ptr || dataType.Kind() == reflect.Slice) {
+		dataType = dataType.Elem()
+	}
+	if dataType == nil {
+		return "<nil>"
+	}
+	return dataType.Name()
+}
@@ -0,0 +1,260 @@
+package harvest
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeExtraFields(t *testing.T) {
+	project := &Project{}
+	data := []byte(`{"id":12,"name":"Foo","color":"red","Billable":true,"estimate":{"hours":3}}`)
+
+	unknownFields, err := decodeExtraFields(data, project)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	expectedFields := []string{"color", "estimate"}
+
+	if !reflect.DeepEqual(expectedFields, unknownFields) {
+		t.Logf("Expected unknown fields to equal %v, got %v\n", expectedFields, unknownFields)
+		t.Fail()
+	}
+
+	expectedExtra := ExtraFields{
+		"color":    json.RawMessage(`"red"`),
+		"estimate": json.RawMessage(`{"hours":3}`),
+	}
+
+	if !reflect.DeepEqual(expectedExtra, project.Extra) {
+		t.Logf("Expected extra fields to equal %s, got %s\n", expectedExtra, project.Extra)
+		t.Fail()
+	}
+
+	// No unknown fields
+	unknownFields, err = decodeExtraFields([]byte(`{"id":12}`), project)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if unknownFields != nil {
+		t.Logf("Expected unknown fields to be nil, got %v\n", unknownFields)
+		t.Fail()
+	}
+
+	if project.Extra != nil {
+		t.Logf("Expected extra fields to be nil, got %s\n", project.Extra)
+		t.Fail()
+	}
+
+	// Model without ExtraFields
+	unknownFields, err = decodeExtraFields([]byte(`{"ID":12,"foo":"bar"}`), &testPayload{})
+
+	if !reflect.DeepEqual([]string{"foo"}, unknownFields) {
+		t.Logf("Expected unknown fields to equal %v, got %v\n", []string{"foo"}, unknownFields)
+		t.Fail()
+	}
+}
+
+func TestEncodeExtraFields(t *testing.T) {
+	project := &Project{
+		ID:   12,
+		Name: "Foo",
+		Extra: ExtraFields{
+			"color": json.RawMessage(`"red"`),
+			"name":  json.RawMessage(`"Bar"`),
+		},
+	}
+	marshaled, _ := json.Marshal(project)
+
+	encoded, err := encodeExtraFields(marshaled, project)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	var actual map[string]interface{}
+	json.Unmarshal(encoded, &actual)
+
+	if actual["color"] != "red" {
+		t.Logf("Expected color to equal 'red', got %v\n", actual["color"])
+		t.Fail()
+	}
+
+	if actual["name"] != "Foo" {
+		t.Logf("Expected name to equal 'Foo', got %v\n", actual["name"])
+		t.Fail()
+	}
+}
+
+func TestJsonApiExtraFieldsRoundTrip(t *testing.T) {
+	testClient := &testHttpClient{}
+	api := createJsonTestApi(testClient)
+	testClient.setResponseBody(http.StatusOK, strings.NewReader(`{"client":{"id":12,"name":"Foo","color":"red"}}`))
+
+	var client Client
+
+	err := api.Find(12, &client, nil)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if string(client.Extra["color"]) != `"red"` {
+		t.Logf("Expected extra field color to equal %q, got %q\n", `"red"`, string(client.Extra["color"]))
+		t.Fail()
+	}
+
+	testClient.setResponseBody(http.StatusOK, emptyReader())
+
+	err = api.Update(&client)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	var requestPayload map[string]map[string]interface{}
+	json.NewDecoder(testClient.testRequest.Body).Decode(&requestPayload)
+
+	if requestPayload["client"]["color"] != "red" {
+		t.Logf("Expected request to contain color 'red', got %+#v\n", requestPayload)
+		t.Fail()
+	}
+
+	// All
+	testClient.setResponseBody(http.StatusOK, strings.NewReader(`[{"client":{"id":12,"color":"red"}},{"client":{"id":13}}]`))
+
+	var clients []*Client
+
+	err = api.All(&clients, nil)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if len(clients) != 2 {
+		t.Logf("Expected 2 clients, got %d\n", len(clients))
+		t.FailNow()
+	}
+
+	if string(clients[0].Extra["color"]) != `"red"` {
+		t.Logf("Expected extra field color to equal %q, got %q\n", `"red"`, string(clients[0].Extra["color"]))
+		t.Fail()
+	}
+
+	if clients[1].Extra != nil {
+		t.Logf("Expected extra fields to be nil, got %s\n", clients[1].Extra)
+		t.Fail()
+	}
+}
+
+func TestJsonApiStrictMode(t *testing.T) {
+	testClient := &testHttpClient{}
+	api := createJsonTestApi(testClient)
+	api.SetStrict(true)
+	testClient.setResponseBody(http.StatusOK, strings.NewReader(`{"client":{"id":12,"color":"red"}}`))
+
+	var client Client
+
+	err := api.Find(12, &client, nil)
+
+	if err == nil {
+		t.Logf("Expected error, got nil\n")
+		t.FailNow()
+	}
+
+	unknownFieldsError, ok := err.(*UnknownFieldsError)
+	if !ok {
+		t.Logf("Expected error of type *UnknownFieldsError, got %T\n", err)
+		t.FailNow()
+	}
+
+	expected := &UnknownFieldsError{Type: "Client", Fields: []string{"color"}}
+
+	if !reflect.DeepEqual(expected, unknownFieldsError) {
+		t.Logf("Expected error to equal %+#v, got %+#v\n", expected, unknownFieldsError)
+		t.Fail()
+	}
+
+	if client.ID != 12 {
+		t.Logf("Expected client to be populated, got %+#v\n", client)
+		t.Fail()
+	}
+
+	// All
+	testClient.setResponseBody(http.StatusOK, strings.NewReader(`[{"client":{"id":12,"color":"red"}},{"client":{"id":13,"size":3}}]`))
+
+	var clients []*Client
+
+	err = api.All(&clients, nil)
+
+	expected = &UnknownFieldsError{Type: "Client", Fields: []string{"color", "size"}}
+
+	if !reflect.DeepEqual(expected, err) {
+		t.Logf("Expected error to equal %+#v, got %+#v\n", expected, err)
+		t.Fail()
+	}
+}
+
+func TestJsonApiStrictModeDerivedEndpoints(t *testing.T) {
+	testClient := &testHttpClient{}
+	api := createJsonTestApi(testClient)
+	api.SetStrict(true)
+	endpoint := api.forPath("day_entries")
+
+	if !endpoint.Strict() {
+		t.Logf("Expected derived endpoint to be strict\n")
+		t.Fail()
+	}
+
+	api.SetStrict(false)
+
+	if endpoint.Strict() {
+		t.Logf("Expected derived endpoint not to be strict\n")
+		t.Fail()
+	}
+
+	if (&JsonApi{}).Strict() {
+		t.Logf("Expected zero value not to be strict\n")
+		t.Fail()
+	}
+}
+
+func TestDecodeExtraFieldsDayEntryAndExpense(t *testing.T) {
+	var tests = []struct {
+		model ExtraFieldsModel
+	}{
+		{&DayEntry{}},
+		{&Expense{}},
+		{&Timesheet{}},
+	}
+	for _, test := range tests {
+		unknownFields, err := decodeExtraFields([]byte(`{"user-id":12,"external_ref":"x"}`), test.model)
+
+		if err != nil {
+			t.Logf("Expected no error, got %T: %v\n", err, err)
+			t.Fail()
+		}
+
+		if !reflect.DeepEqual([]string{"external_ref"}, unknownFields) {
+			t.Logf("Expected unknown fields of %T to equal [external_ref], got %v\n", test.model, unknownFields)
+			t.Fail()
+		}
+
+		if string((*test.model.ExtraFields())["external_ref"]) != `"x"` {
+			t.Logf("Expected extra field external_ref of %T to be preserved, got %v\n", test.model, *test.model.ExtraFields())
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Package format renders dates, times, hours and monetary values the way
+// Harvest displays them to a given account.
+package format
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mitch000001/go-harvest/harvest"
+)
+
+// DefaultDateLayout is the layout used for dates if the Formatter has no
+// DateLayout set
+const DefaultDateLayout = "02 Jan 2006"
+
+// currencySymbols maps ISO currency codes to the symbols shown by Harvest
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"CAD": "$",
+	"AUD": "$",
+	"NZD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"INR": "₹",
+}
+
+// Formatter formats values according to the settings of an account. The
+// zero value formats with the Harvest defaults: weeks starting on Monday,
+// decimal hours, 12h clock in UTC, period as decimal symbol and comma as
+// thousands separator.
+type Formatter struct {
+	company harvest.Company
+	// location is the timezone of the account user
+	location *time.Location
+	// DateLayout is the time layout used for dates, see DefaultDateLayout
+	DateLayout string
+}
+
+// New returns a Formatter for the given account. Missing settings fall back
+// to the Harvest defaults.
+func New(account *harvest.Account) *Formatter {
+	formatter := &Formatter{}
+	if account == nil {
+		return formatter
+	}
+	if account.Company != nil {
+		formatter.company = *account.Company
+	}
+	if account.User != nil {
+		formatter.location = account.User.Location()
+	}
+	return formatter
+}
+
+// settings returns the company settings used for formatting, with missing
+// settings replaced by the Harvest defaults. The thousands separator defaults
+// to a period if the decimal symbol is a comma.
+func (f *Formatter) settings() *harvest.Company {
+	company := f.company
+	if company.WeekStartDay == "" {
+		company.WeekStartDay = harvest.Monday
+	}
+	if company.TimeFormat == "" {
+		company.TimeFormat = harvest.Decimal
+	}
+	if company.Clock == "" {
+		company.Clock = harvest.H12
+	}
+	if company.DecimalSymbol == "" {
+		company.DecimalSymbol = harvest.PeriodDS
+	}
+	if company.ThousandsSeparator == "" {
+		company.ThousandsSeparator = harvest.CommaTS
+		if company.DecimalSymbol == harvest.CommaDS {
+			company.ThousandsSeparator = harvest.PeriodTS
+		}
+	}
+	return &company
+}
+
+// timezone returns the timezone of the account user, defaulting to UTC
+func (f *Formatter) timezone() *time.Location {
+	if f.location == nil {
+		return time.UTC
+	}
+	return f.location
+}
+
+// FromHarvest fetches the account of the client and returns a Formatter for
+// it
+func FromHarvest(client *harvest.Harvest) (*Formatter, error) {
+	account, err := client.Account()
+	if err != nil {
+		return nil, err
+	}
+	return New(account), nil
+}
+
+// Date formats the date with the DateLayout of the Formatter
+func (f *Formatter) Date(date harvest.ShortDate) string {
+	if date.IsZero() {
+		return ""
+	}
+	layout := f.DateLayout
+	if layout == "" {
+		layout = DefaultDateLayout
+	}
+	return date.Format(layout)
+}
+
+// Week formats the week containing date, starting at the week start day of
+// the account, e.g. "Week of 02 Feb 2015"
+func (f *Formatter) Week(date harvest.ShortDate) string {
+	week := harvest.TimeframeForWeek(date, f.settings().WeekStartDay)
+	return fmt.Sprintf("Week of %s", f.Date(week.StartDate))
+}
+
+// Time formats the time of day of t within the timezone of the account user
+// using its clock format, e.g. "3:04pm" or "15:04"
+func (f *Formatter) Time(t time.Time) string {
+	t = t.In(f.timezone())
+	if f.settings().Clock == harvest.H24 {
+		return t.Format("15:04")
+	}
+	return t.Format("3:04pm")
+}
+
+// Hours formats h with the time format, decimal symbol and thousands
+// separator of the account
+func (f *Formatter) Hours(h harvest.Hours) string {
+	return f.settings().FormatHours(h)
+}
+
+// Money formats m with the decimal symbol and thousands separator of the
+// account, prefixed with the symbol of its currency, e.g. "$1,234.50". If no
+// symbol is known, the currency code is used, e.g. "CHF 1,234.50".
+func (f *Formatter) Money(m harvest.Money) string {
+	settings := f.settings()
+	amount := m.Format(settings.DecimalSymbol, settings.ThousandsSeparator)
+	sign := ""
+	if strings.HasPrefix(amount, "-") {
+		sign = "-"
+		amount = amount[1:]
+	}
+	currency := m.Currency()
+	if symbol, ok := currencySymbols[currency]; ok {
+		return sign + symbol + amount
+	}
+	if currency == "" {
+		return sign + amount
+	}
+	return fmt.Sprintf("%s%s %s", sign, currency, amount)
+}
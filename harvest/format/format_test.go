@@ -0,0 +1,112 @@
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mitch000001/go-harvest/harvest"
+)
+
+func mustMoney(t *testing.T, amount string, currency string) harvest.Money {
+	money, err := harvest.NewMoney(amount, currency)
+	if err != nil {
+		t.Fatalf("Expected no error, got %T: %v\n", err, err)
+	}
+	return money
+}
+
+func TestNewDefaults(t *testing.T) {
+	formatter := New(nil)
+
+	hours := formatter.Hours(90 * harvest.Minute)
+
+	if hours != "1.50" {
+		t.Logf("Expected hours to equal %q, got %q\n", "1.50", hours)
+		t.Fail()
+	}
+
+	money := formatter.Money(mustMoney(t, "1234.5", "USD"))
+
+	if money != "$1,234.50" {
+		t.Logf("Expected money to equal %q, got %q\n", "$1,234.50", money)
+		t.Fail()
+	}
+
+	clock := formatter.Time(time.Date(2015, 2, 3, 15, 4, 0, 0, time.UTC))
+
+	if clock != "3:04pm" {
+		t.Logf("Expected time to equal %q, got %q\n", "3:04pm", clock)
+		t.Fail()
+	}
+}
+
+func TestZeroValueFormatter(t *testing.T) {
+	formatter := &Formatter{}
+
+	if money := formatter.Money(mustMoney(t, "1234.5", "USD")); money != "$1,234.50" {
+		t.Logf("Expected money to equal %q, got %q\n", "$1,234.50", money)
+		t.Fail()
+	}
+
+	if clock := formatter.Time(time.Date(2015, 2, 3, 15, 4, 0, 0, time.UTC)); clock != "3:04pm" {
+		t.Logf("Expected time to equal %q, got %q\n", "3:04pm", clock)
+		t.Fail()
+	}
+
+	if week := formatter.Week(harvest.Date(2015, 2, 4)); week != "Week of 02 Feb 2015" {
+		t.Logf("Expected week to equal %q, got %q\n", "Week of 02 Feb 2015", week)
+		t.Fail()
+	}
+
+	// a comma as decimal symbol defaults to a period as thousands separator
+	formatter = New(&harvest.Account{Company: &harvest.Company{DecimalSymbol: harvest.CommaDS}})
+
+	if money := formatter.Money(mustMoney(t, "1234.5", "EUR")); money != "€1.234,50" {
+		t.Logf("Expected money to equal %q, got %q\n", "€1.234,50", money)
+		t.Fail()
+	}
+}
+
+func TestFormatterWithAccount(t *testing.T) {
+	account := &harvest.Account{
+		Company: &harvest.Company{
+			WeekStartDay:       harvest.Sunday,
+			TimeFormat:         harvest.HoursMinutes,
+			Clock:              harvest.H24,
+			DecimalSymbol:      harvest.CommaDS,
+			ThousandsSeparator: harvest.PeriodTS,
+		},
+		User: &harvest.AccountUser{TimezoneUtcOffset: 3600},
+	}
+	formatter := New(account)
+
+	var tests = []struct {
+		actual   string
+		expected string
+	}{
+		{formatter.Hours(1234*harvest.Hour + 5*harvest.Minute), "1.234:05"},
+		{formatter.Money(mustMoney(t, "1234.5", "EUR")), "€1.234,50"},
+		{formatter.Money(mustMoney(t, "-12", "CHF")), "-CHF 12,00"},
+		{formatter.Money(mustMoney(t, "7", "")), "7,00"},
+		{formatter.Time(time.Date(2015, 2, 3, 23, 30, 0, 0, time.UTC)), "00:30"},
+		{formatter.Date(harvest.Date(2015, 2, 3)), "03 Feb 2015"},
+		{formatter.Date(harvest.ShortDate{}), ""},
+		// 2015-02-03 is a tuesday
+		{formatter.Week(harvest.Date(2015, 2, 3)), "Week of 01 Feb 2015"},
+	}
+	for _, test := range tests {
+		if test.actual != test.expected {
+			t.Logf("Expected %q, got %q\n", test.expected, test.actual)
+			t.Fail()
+		}
+	}
+
+	formatter.DateLayout = "01/02/2006"
+
+	date := formatter.Date(harvest.Date(2015, 2, 3))
+
+	if date != "02/03/2015" {
+		t.Logf("Expected date to equal %q, got %q\n", "02/03/2015", date)
+		t.Fail()
+	}
+}
@@ -43,29 +43,44 @@ func New(subdomain string, clientProvider func() HttpClient) (*Harvest, error) {
 	api := &JsonApi{
 		Client:  clientProvider,
 		baseUrl: baseUrl,
+		strict:  new(int32),
 	}
 	h := &Harvest{
 		baseUrl: baseUrl,
 		api:     api,
 	}
 	userApi := api.CrudTogglerEndpoint("people")
-	h.Users = NewUserService(api, userApi)
+	h.Users = NewUserService(api, userApi, api)
 	projectApi := api.CrudTogglerEndpoint("projects")
 	h.Projects = NewProjectService(api, projectApi)
 	h.Clients = NewClientService(api.CrudTogglerEndpoint("clients"))
 	taskApi := api.CrudTogglerEndpoint("tasks")
 	h.Tasks = NewTaskService(taskApi, api)
+	h.Invoices = NewInvoiceService(api.CrudEndpoint("invoices"), api)
+	h.RecurringInvoices = NewRecurringInvoiceService(api.CrudEndpoint("recurring_invoices"))
+	h.Retainers = NewRetainerService(api.CrudEndpoint("retainers"), api)
+	h.Approvals = NewApprovalService(api, api)
 	return h, nil
 }
 
+// SetStrictMode enables or disables the strict mode for all services of the
+// client, see JsonApi.SetStrict
+func (h *Harvest) SetStrictMode(strict bool) {
+	h.api.SetStrict(strict)
+}
+
 // Harvest defines the client for requests on the API
 type Harvest struct {
-	api      *JsonApi
-	baseUrl  *url.URL // API endpoint base URL
-	Users    *UserService
-	Projects *ProjectService
-	Clients  *ClientService
-	Tasks    *TaskService
+	api               *JsonApi
+	baseUrl           *url.URL // API endpoint base URL
+	Users             *UserService
+	Projects          *ProjectService
+	Clients           *ClientService
+	Tasks             *TaskService
+	Invoices          *InvoiceService
+	RecurringInvoices *RecurringInvoiceService
+	Retainers         *RetainerService
+	Approvals         *ApprovalService
 }
 
 func (h *Harvest) Account() (*Account, error) {
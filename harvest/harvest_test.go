@@ -86,6 +86,26 @@ func TestNewHarvest(t *testing.T) {
 		t.Fail()
 	}
 
+	if client.Invoices == nil {
+		t.Logf("Expected invoices service not to be nil")
+		t.Fail()
+	}
+
+	if client.RecurringInvoices == nil {
+		t.Logf("Expected recurring invoices service not to be nil")
+		t.Fail()
+	}
+
+	if client.Retainers == nil {
+		t.Logf("Expected retainers service not to be nil")
+		t.Fail()
+	}
+
+	if client.Approvals == nil {
+		t.Logf("Expected approvals service not to be nil")
+		t.Fail()
+	}
+
 	// wrong kind of subdomain
 	client, err = New("", testClientFn)
 
@@ -0,0 +1,201 @@
+package harvest
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Hours is an amount of tracked time with a precision of one second. As it is
+// an integer, sums of Hours are exact.
+type Hours int64
+
+const (
+	Second Hours = 1
+	Minute       = 60 * Second
+	Hour         = 60 * Minute
+)
+
+// ParseHours parses hours given as decimal ("1.5"), as hours and minutes
+// ("1:30") or as duration with units ("90m", "1h30m").
+func ParseHours(value string) (Hours, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("Malformed hours: %q", value)
+	}
+	if strings.Contains(value, ":") {
+		return parseHoursMinutes(value)
+	}
+	if strings.ContainsAny(value, "hms") {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("Malformed hours: %q", value)
+		}
+		return HoursFromDuration(duration), nil
+	}
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return 0, fmt.Errorf("Malformed hours: %q", value)
+	}
+	return hoursFromRat(rat), nil
+}
+
+func parseHoursMinutes(value string) (Hours, error) {
+	sign := Hours(1)
+	if strings.HasPrefix(value, "-") {
+		sign = -1
+		value = value[1:]
+	}
+	parts := strings.Split(value, ":")
+	if len(parts) != 2 || parts[0] == "" || len(parts[1]) != 2 {
+		return 0, fmt.Errorf("Malformed hours: %q", value)
+	}
+	hours, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("Malformed hours: %q", value)
+	}
+	minutes, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil || minutes >= 60 {
+		return 0, fmt.Errorf("Malformed hours: %q", value)
+	}
+	return sign * (Hours(hours)*Hour + Hours(minutes)*Minute), nil
+}
+
+// HoursFromFloat returns the decimal hours h rounded to the nearest second
+func HoursFromFloat(h float64) Hours {
+	rat := new(big.Rat)
+	if rat.SetFloat64(h) == nil {
+		return 0
+	}
+	return hoursFromRat(rat)
+}
+
+// HoursFromDuration returns d rounded to the nearest second
+func HoursFromDuration(d time.Duration) Hours {
+	return Hours(d.Round(time.Second) / time.Second)
+}
+
+func hoursFromRat(hours *big.Rat) Hours {
+	seconds := new(big.Rat).Mul(hours, big.NewRat(int64(Hour), 1))
+	return Hours(roundRat(seconds, RoundHalfEven).Int64())
+}
+
+// Float64 returns the hours as decimal number
+func (h Hours) Float64() float64 {
+	return float64(h) / float64(Hour)
+}
+
+// Duration returns the hours as time.Duration
+func (h Hours) Duration() time.Duration {
+	return time.Duration(h) * time.Second
+}
+
+// Round rounds h to a multiple of increment with the given mode, e.g. to
+// quarter hours with Round(15*Minute, RoundUp). It returns h unchanged if
+// increment is not positive.
+func (h Hours) Round(increment Hours, mode RoundingMode) Hours {
+	if increment <= 0 {
+		return h
+	}
+	multiple := roundRat(big.NewRat(int64(h), int64(increment)), mode)
+	return Hours(multiple.Int64()) * increment
+}
+
+// SumHours returns the sum of all values
+func SumHours(values ...Hours) Hours {
+	var sum Hours
+	for _, v := range values {
+		sum += v
+	}
+	return sum
+}
+
+// String returns the hours as decimal with two decimal places, e.g. "1.50"
+func (h Hours) String() string {
+	return h.Format(Decimal, PeriodDS, "")
+}
+
+// Format formats the hours according to the time format, decimal symbol and
+// thousands separator. Decimal hours are rounded to two decimal places,
+// HoursMinutes to whole minutes.
+func (h Hours) Format(format TimeFormat, decimalSymbol DecimalSymbol, thousandsSeparator ThousandsSeparator) string {
+	sign := ""
+	if h < 0 {
+		sign = "-"
+		h = -h
+	}
+	if format == HoursMinutes {
+		minutes := int64(h.Round(Minute, RoundHalfUp) / Minute)
+		return fmt.Sprintf("%s%s:%02d", sign, groupThousands(minutes/60, thousandsSeparator), minutes%60)
+	}
+	if decimalSymbol == "" {
+		decimalSymbol = PeriodDS
+	}
+	hundredths := h.Round(Hour/100, RoundHalfUp) / (Hour / 100)
+	return fmt.Sprintf("%s%s%s%02d", sign, groupThousands(int64(hundredths/100), thousandsSeparator), decimalSymbol, hundredths%100)
+}
+
+// groupThousands formats the non negative integer n with separator between
+// groups of three digits
+func groupThousands(n int64, separator ThousandsSeparator) string {
+	digits := strconv.FormatInt(n, 10)
+	if separator == "" || len(digits) <= 3 {
+		return digits
+	}
+	var grouped []string
+	for len(digits) > 3 {
+		grouped = append([]string{digits[len(digits)-3:]}, grouped...)
+		digits = digits[:len(digits)-3]
+	}
+	grouped = append([]string{digits}, grouped...)
+	return strings.Join(grouped, string(separator))
+}
+
+// MarshalJSON marshals the hours as decimal JSON number
+func (h Hours) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatFloat(h.Float64(), 'f', -1, 64)), nil
+}
+
+// UnmarshalJSON accepts JSON numbers, strings in any format understood by
+// ParseHours and null. Empty strings and null are treated as zero.
+func (h *Hours) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	value := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		var err error
+		value, err = strconv.Unquote(value)
+		if err != nil {
+			return err
+		}
+	}
+	if value == "" || value == "null" {
+		*h = 0
+		return nil
+	}
+	hours, err := ParseHours(value)
+	if err != nil {
+		return err
+	}
+	*h = hours
+	return nil
+}
+
+// FormatHours formats h according to the time settings of the company
+func (c *Company) FormatHours(h Hours) string {
+	return h.Format(c.TimeFormat, c.DecimalSymbol, c.ThousandsSeparator)
+}
+
+// ParseHours parses hours like ParseHours, but accepts the decimal symbol and
+// thousands separator of the company, e.g. "1.234,5".
+func (c *Company) ParseHours(value string) (Hours, error) {
+	if c.ThousandsSeparator != "" {
+		value = strings.Replace(value, string(c.ThousandsSeparator), "", -1)
+	}
+	if c.DecimalSymbol != "" && c.DecimalSymbol != PeriodDS {
+		value = strings.Replace(value, string(c.DecimalSymbol), ".", -1)
+	}
+	return ParseHours(value)
+}
@@ -0,0 +1,164 @@
+package harvest
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseHours(t *testing.T) {
+	var tests = []struct {
+		value       string
+		expected    Hours
+		expectError bool
+	}{
+		{"1.5", 90 * Minute, false},
+		{"0.25", 15 * Minute, false},
+		{"8", 8 * Hour, false},
+		{"1:30", 90 * Minute, false},
+		{"0:05", 5 * Minute, false},
+		{"-1:15", -75 * Minute, false},
+		{"90m", 90 * Minute, false},
+		{"1h30m", 90 * Minute, false},
+		{"1:75", 0, true},
+		{"1:5", 0, true},
+		{"abc", 0, true},
+		{"", 0, true},
+	}
+	for _, test := range tests {
+		hours, err := ParseHours(test.value)
+
+		if test.expectError {
+			if err == nil {
+				t.Logf("Expected error for %q, got nil\n", test.value)
+				t.Fail()
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Logf("Expected no error for %q, got %T: %v\n", test.value, err, err)
+			t.Fail()
+		}
+
+		if hours != test.expected {
+			t.Logf("Expected %q to parse to %v, got %v\n", test.value, test.expected, hours)
+			t.Fail()
+		}
+	}
+}
+
+func TestSumHoursWithoutDrift(t *testing.T) {
+	var values []Hours
+	for i := 0; i < 10; i++ {
+		values = append(values, HoursFromFloat(0.1))
+	}
+
+	sum := SumHours(values...)
+
+	if sum != Hour {
+		t.Logf("Expected sum to equal %v, got %v\n", Hour, sum)
+		t.Fail()
+	}
+}
+
+func TestHoursRound(t *testing.T) {
+	var tests = []struct {
+		hours     Hours
+		increment Hours
+		mode      RoundingMode
+		expected  Hours
+	}{
+		{62 * Minute, 15 * Minute, RoundUp, 75 * Minute},
+		{62 * Minute, 15 * Minute, RoundHalfUp, 60 * Minute},
+		{68 * Minute, 15 * Minute, RoundHalfUp, 75 * Minute},
+		{74 * Minute, 15 * Minute, RoundDown, 60 * Minute},
+		{74 * Minute, 0, RoundDown, 74 * Minute},
+	}
+	for _, test := range tests {
+		rounded := test.hours.Round(test.increment, test.mode)
+
+		if rounded != test.expected {
+			t.Logf("Expected %v rounded to %v to equal %v, got %v\n", test.hours, test.increment, test.expected, rounded)
+			t.Fail()
+		}
+	}
+}
+
+func TestHoursFormat(t *testing.T) {
+	var tests = []struct {
+		hours    Hours
+		company  Company
+		expected string
+	}{
+		{90 * Minute, Company{TimeFormat: Decimal, DecimalSymbol: PeriodDS}, "1.50"},
+		{90 * Minute, Company{TimeFormat: Decimal, DecimalSymbol: CommaDS}, "1,50"},
+		{90 * Minute, Company{TimeFormat: HoursMinutes}, "1:30"},
+		{20 * Minute, Company{TimeFormat: Decimal}, "0.33"},
+		{-20 * Minute, Company{TimeFormat: HoursMinutes}, "-0:20"},
+		{1234*Hour + 30*Minute, Company{TimeFormat: Decimal, DecimalSymbol: CommaDS, ThousandsSeparator: PeriodTS}, "1.234,50"},
+		{1234*Hour + 30*Minute, Company{TimeFormat: HoursMinutes, ThousandsSeparator: Apostrophe}, "1'234:30"},
+	}
+	for _, test := range tests {
+		formatted := test.company.FormatHours(test.hours)
+
+		if formatted != test.expected {
+			t.Logf("Expected %d seconds to format to %q, got %q\n", int64(test.hours), test.expected, formatted)
+			t.Fail()
+		}
+	}
+}
+
+func TestCompanyParseHours(t *testing.T) {
+	company := Company{DecimalSymbol: CommaDS, ThousandsSeparator: PeriodTS}
+
+	hours, err := company.ParseHours("1.234,5")
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if hours != 1234*Hour+30*Minute {
+		t.Logf("Expected hours to equal %v, got %v\n", 1234*Hour+30*Minute, hours)
+		t.Fail()
+	}
+}
+
+func TestHoursJSON(t *testing.T) {
+	var entry DayEntry
+	err := json.Unmarshal([]byte(`{"id":1,"hours":1.25}`), &entry)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if entry.Hours != 75*Minute {
+		t.Logf("Expected hours to equal %v, got %v\n", 75*Minute, entry.Hours)
+		t.Fail()
+	}
+
+	err = json.Unmarshal([]byte(`{"id":1,"hours":"2:15"}`), &entry)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if entry.Hours != 135*Minute {
+		t.Logf("Expected hours to equal %v, got %v\n", 135*Minute, entry.Hours)
+		t.Fail()
+	}
+
+	marshaled, err := json.Marshal(90 * Minute)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if string(marshaled) != "1.5" {
+		t.Logf("Expected hours to marshal to %q, got %q\n", "1.5", marshaled)
+		t.Fail()
+	}
+}
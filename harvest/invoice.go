@@ -1,13 +1,16 @@
 package harvest
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 //go:generate go run ../cmd/api_gen/api_gen.go -type=Invoice -c -fields CrudEndpointProvider
 
 type Invoice struct {
 	ID        int       `json:"id"`
-	Amount    float64   `json:"amount"`
-	DueAmount float64   `json:"due-amount"`
+	Amount    Money     `json:"amount"`
+	DueAmount Money     `json:"due-amount"`
 	DueAt     ShortDate `json:"due-at"`
 	// human representation for due at
 	DueAtHumanFormat string `json:"due-at-human-format"`
@@ -25,17 +28,17 @@ type Invoice struct {
 	PurchaseOrder string    `json:"purchase-order"`
 	ClientKey     string    `json:"client-key"`
 	// See invoice messages and invoice payments for manipulating the state attribute.  Direct assigment will be ignored. Options are open, draft, partial, paid and closed
-	State string `json:"state"`
+	State InvoiceState `json:"state"`
 	// applied tax percentage, blank if not taxed
 	Tax float64 `json:"tax"`
 	// applied tax 2 percentage, blank if not taxed
 	Tax2 float64 `json:"tax2"`
 	// the first tax amount
-	TaxAmount float64 `json:"tax-amount"`
+	TaxAmount Money `json:"tax-amount"`
 	// the second tax amount
-	TaxAmount2 float64 `json:"tax-amount2"`
+	TaxAmount2 Money `json:"tax-amount2"`
 	// discount
-	DiscountAmount float64 `json:"discount-amount"`
+	DiscountAmount Money   `json:"discount-amount"`
 	Discount       float64 `json:"discount"`
 	// is it recurring?
 	RecurringInvoiceId int `json:"recurring-invoice-id"`
@@ -53,7 +56,7 @@ type Invoice struct {
 	   task:       gathers content from Harvest grouping by task
 	   people:     gathers content from Harvest grouping by people
 	   detailed:   includes detailed notes */
-	Kind string `json:"kind"`
+	Kind InvoiceKind `json:"kind"`
 	// comma separated project ids to gather data from, useless on free_form invoices
 	ProjectsToInvoice string `json:"projects-to-invoice"`
 	// import hours useless on free_form invoices
@@ -63,6 +66,8 @@ type Invoice struct {
 	// invoiced period for expenses, present for generated invoices
 	ExpensePeriodEnd   ShortDate `json:"expense-period-end"`
 	ExpensePeriodStart ShortDate `json:"expense-period-start"`
+	// JSON attributes not modeled by Invoice
+	Extra ExtraFields `json:"-"`
 }
 
 func (i *Invoice) Id() int {
@@ -73,6 +78,139 @@ func (i *Invoice) SetId(id int) {
 	i.ID = id
 }
 
+func (i *Invoice) ExtraFields() *ExtraFields {
+	return &i.Extra
+}
+
 func (i *Invoice) Type() string {
 	return "Invoice"
 }
+
+// UnmarshalJSON unmarshals the invoice and sets its currency on all amounts
+func (i *Invoice) UnmarshalJSON(data []byte) error {
+	type invoice Invoice
+	err := json.Unmarshal(data, (*invoice)(i))
+	if err != nil {
+		return err
+	}
+	i.Amount = i.Amount.In(i.Currency)
+	i.DueAmount = i.DueAmount.In(i.Currency)
+	i.TaxAmount = i.TaxAmount.In(i.Currency)
+	i.TaxAmount2 = i.TaxAmount2.In(i.Currency)
+	i.DiscountAmount = i.DiscountAmount.In(i.Currency)
+	return nil
+}
+
+// Validate checks the taxes of the invoice
+func (i *Invoice) Validate() error {
+	validationError := &ValidationError{Type: i.Type()}
+	if i.Tax < 0 || i.Tax > 100 {
+		validationError.add("tax", "must be between 0 and 100, got %v", i.Tax)
+	}
+	if i.Tax2 < 0 || i.Tax2 > 100 {
+		validationError.add("tax2", "must be between 0 and 100, got %v", i.Tax2)
+	}
+	return validationError.errorOrNil()
+}
+
+// IsRecurring returns true if the invoice was issued by a recurring invoice
+// profile
+func (i *Invoice) IsRecurring() bool {
+	return i.RecurringInvoiceId != 0
+}
+
+// FundsRetainer returns true if the invoice funds a retainer
+func (i *Invoice) FundsRetainer() bool {
+	return i.RetainerId != 0
+}
+
+type InvoiceKind string
+
+const (
+	FreeFormInvoice InvoiceKind = "free_form"
+	ProjectInvoice  InvoiceKind = "project"
+	TaskInvoice     InvoiceKind = "task"
+	PeopleInvoice   InvoiceKind = "people"
+	DetailedInvoice InvoiceKind = "detailed"
+)
+
+// IsValid returns true if k is one of the defined InvoiceKind values or empty
+func (k InvoiceKind) IsValid() bool {
+	switch k {
+	case "", FreeFormInvoice, ProjectInvoice, TaskInvoice, PeopleInvoice, DetailedInvoice:
+		return true
+	}
+	return false
+}
+
+func (k InvoiceKind) MarshalJSON() ([]byte, error) {
+	return marshalEnum(string(k))
+}
+
+func (k *InvoiceKind) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data)
+	if err != nil {
+		return err
+	}
+	*k = InvoiceKind(value)
+	return nil
+}
+
+type InvoiceState string
+
+const (
+	OpenInvoice    InvoiceState = "open"
+	DraftInvoice   InvoiceState = "draft"
+	PartialInvoice InvoiceState = "partial"
+	PaidInvoice    InvoiceState = "paid"
+	ClosedInvoice  InvoiceState = "closed"
+)
+
+// IsValid returns true if s is one of the defined InvoiceState values or empty
+func (s InvoiceState) IsValid() bool {
+	switch s {
+	case "", OpenInvoice, DraftInvoice, PartialInvoice, PaidInvoice, ClosedInvoice:
+		return true
+	}
+	return false
+}
+
+func (s InvoiceState) MarshalJSON() ([]byte, error) {
+	return marshalEnum(string(s))
+}
+
+func (s *InvoiceState) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data)
+	if err != nil {
+		return err
+	}
+	*s = InvoiceState(value)
+	return nil
+}
+
+// InvoiceStatus is used to filter invoices, see Params.Status
+type InvoiceStatus string
+
+const (
+	// sent to the client but no payment recieved
+	OpenStatus InvoiceStatus = "open"
+	// partial payment was recorded
+	PartialStatus InvoiceStatus = "partial"
+	// Harvest did not sent this to a client, nor recorded any payments
+	DraftStatus InvoiceStatus = "draft"
+	// invoice paid in full
+	PaidStatus InvoiceStatus = "paid"
+	// unpaid invoices
+	UnpaidStatus InvoiceStatus = "unpaid"
+	// past due invoices
+	PastDueStatus InvoiceStatus = "pastdue"
+)
+
+// IsValid returns true if s is one of the defined InvoiceStatus values
+func (s InvoiceStatus) IsValid() bool {
+	switch s {
+	case OpenStatus, PartialStatus, DraftStatus, PaidStatus, UnpaidStatus, PastDueStatus:
+		return true
+	}
+	return false
+}
@@ -6,3 +6,35 @@ func (i *InvoiceService) PublicURL(invoice *Invoice) string {
 	url := i.endpoint.URL()
 	return fmt.Sprintf("%s/client/invoices/%s", url.String(), invoice.ClientKey)
 }
+
+// RecurringInvoice populates recurringInvoice with the recurring invoice
+// profile which issued the given invoice.
+//
+// It returns an error if the invoice is not recurring.
+func (i *InvoiceService) RecurringInvoice(invoice *Invoice, recurringInvoice *RecurringInvoice) error {
+	if !invoice.IsRecurring() {
+		return fmt.Errorf("Invoice with id %d is not recurring", invoice.Id())
+	}
+	endpoint := i.provider.CrudEndpoint("recurring_invoices")
+	return NewRecurringInvoiceService(endpoint).Find(invoice.RecurringInvoiceId, recurringInvoice, nil)
+}
+
+// Retainer populates retainer with the retainer funded by the given invoice.
+//
+// It returns an error if the invoice does not fund a retainer.
+func (i *InvoiceService) Retainer(invoice *Invoice, retainer *Retainer) error {
+	if !invoice.FundsRetainer() {
+		return fmt.Errorf("Invoice with id %d does not fund a retainer", invoice.Id())
+	}
+	endpoint := i.provider.CrudEndpoint("retainers")
+	return NewRetainerService(endpoint, i.provider).Find(invoice.RetainerId, retainer, nil)
+}
+
+// Query populates invoices with all invoices matching query
+func (i *InvoiceService) Query(invoices *[]*Invoice, query InvoiceQuery) error {
+	params, err := query.Values()
+	if err != nil {
+		return err
+	}
+	return i.All(invoices, params)
+}
@@ -22,22 +22,37 @@ func NewInvoiceService(endpoint CrudEndpoint, provider CrudEndpointProvider) *In
 	return &service
 }
 
+// Typed returns the typed service for invoices
+func (s *InvoiceService) Typed() *Service[Invoice, *Invoice] {
+	return NewService[Invoice](s.endpoint)
+}
+
 func (s *InvoiceService) All(invoices *[]*Invoice, params url.Values) error {
-	return s.endpoint.All(invoices, params)
+	all, err := s.Typed().All(params)
+	if all != nil {
+		*invoices = all
+	}
+	return err
 }
 
 func (s *InvoiceService) Find(id int, invoice *Invoice, params url.Values) error {
-	return s.endpoint.Find(id, invoice, params)
+	found, err := s.Typed().Find(id, params)
+	if found != nil {
+		*invoice = *found
+	}
+	return err
 }
 
 func (s *InvoiceService) Create(invoice *Invoice) error {
-	return s.endpoint.Create(invoice)
+	return s.Typed().Create(invoice)
 }
 
-func (s *InvoiceService) Update(invoice *Invoice) error {
-	return s.endpoint.Update(invoice)
+// Update updates the given invoice. If fields are provided, only these
+// fields are sent to the API, see NewPatch.
+func (s *InvoiceService) Update(invoice *Invoice, fields ...string) error {
+	return s.Typed().Update(invoice, fields...)
 }
 
 func (s *InvoiceService) Delete(invoice *Invoice) error {
-	return s.endpoint.Delete(invoice)
+	return s.Typed().Delete(invoice)
 }
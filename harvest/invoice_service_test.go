@@ -0,0 +1,102 @@
+package harvest
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInvoiceServiceRecurringInvoice(t *testing.T) {
+	called := false
+	testData := apiWrapperTestData{
+		expectedIdType:       reflect.TypeOf(12),
+		expectedDataType:     reflect.TypeOf(&RecurringInvoice{}),
+		expectedErrorMessage: "ERR",
+	}
+	provider := &testCrudEndpointProvider{endpoint: testApiFindWrapper(&testData, &called)}
+	service := NewInvoiceService(nil, provider)
+
+	err := service.RecurringInvoice(&Invoice{ID: 1, RecurringInvoiceId: 12}, &RecurringInvoice{})
+
+	if !called {
+		t.Logf("Expected Api.Find method to have been called, was not.\n")
+		t.Fail()
+	}
+
+	if errors := testData.getErrors(); errors != "" {
+		t.Logf("Found errors:\n%s", errors)
+		t.Fail()
+	}
+
+	if provider.path != "recurring_invoices" {
+		t.Logf("Expected path to equal 'recurring_invoices', got %q\n", provider.path)
+		t.Fail()
+	}
+
+	if err == nil || err.Error() != "ERR" {
+		t.Logf("Expected error with message 'ERR', got %v\n", err)
+		t.Fail()
+	}
+
+	// Invoice is not recurring
+	called = false
+
+	err = service.RecurringInvoice(&Invoice{ID: 1}, &RecurringInvoice{})
+
+	if called {
+		t.Logf("Expected Api.Find method not to have been called, was called.\n")
+		t.Fail()
+	}
+
+	if err == nil {
+		t.Logf("Expected error, got nil\n")
+		t.Fail()
+	}
+}
+
+func TestInvoiceServiceRetainer(t *testing.T) {
+	called := false
+	testData := apiWrapperTestData{
+		expectedIdType:       reflect.TypeOf(12),
+		expectedDataType:     reflect.TypeOf(&Retainer{}),
+		expectedErrorMessage: "ERR",
+	}
+	provider := &testCrudEndpointProvider{endpoint: testApiFindWrapper(&testData, &called)}
+	service := NewInvoiceService(nil, provider)
+
+	err := service.Retainer(&Invoice{ID: 1, RetainerId: 12}, &Retainer{})
+
+	if !called {
+		t.Logf("Expected Api.Find method to have been called, was not.\n")
+		t.Fail()
+	}
+
+	if errors := testData.getErrors(); errors != "" {
+		t.Logf("Found errors:\n%s", errors)
+		t.Fail()
+	}
+
+	if provider.path != "retainers" {
+		t.Logf("Expected path to equal 'retainers', got %q\n", provider.path)
+		t.Fail()
+	}
+
+	if err == nil || err.Error() != "ERR" {
+		t.Logf("Expected error with message 'ERR', got %v\n", err)
+		t.Fail()
+	}
+
+	// Invoice does not fund a retainer
+	called = false
+
+	err = service.Retainer(&Invoice{ID: 1}, &Retainer{})
+
+	if called {
+		t.Logf("Expected Api.Find method not to have been called, was called.\n")
+		t.Fail()
+	}
+
+	if err == nil {
+		t.Logf("Expected error, got nil\n")
+		t.Fail()
+	}
+}
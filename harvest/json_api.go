@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -72,6 +73,29 @@ type JsonApi struct {
 	baseUrl *url.URL          // API base URL
 	path    string            // API endpoint path
 	Client  func() HttpClient // HTTP Client to do the requests
+	strict  *int32            // strict mode, shared with all endpoints of the API
+}
+
+// SetStrict enables or disables the strict mode of the API and all endpoints
+// derived from it. In strict mode, Find and All return an
+// *UnknownFieldsError if the API responds with fields the models do not know
+// about. The data are populated nevertheless.
+//
+// It is safe to call SetStrict while requests are in flight.
+func (a *JsonApi) SetStrict(strict bool) {
+	if a.strict == nil {
+		a.strict = new(int32)
+	}
+	var value int32
+	if strict {
+		value = 1
+	}
+	atomic.StoreInt32(a.strict, value)
+}
+
+// Strict returns true if unknown JSON fields are reported as error
+func (a *JsonApi) Strict() bool {
+	return a.strict != nil && atomic.LoadInt32(a.strict) == 1
 }
 
 func (a *JsonApi) URL() url.URL {
@@ -99,6 +123,7 @@ func (a *JsonApi) forPath(path string) *JsonApi {
 		baseUrl: a.baseUrl,
 		path:    path,
 		Client:  a.Client,
+		strict:  a.strict,
 	}
 }
 
@@ -152,6 +177,10 @@ func (a *JsonApi) Process(method string, path string, body io.Reader) (*http.Res
 // endpoint
 //
 // params contains additional query parameters and may be nil
+//
+// Attributes unknown to the resource are stored within its ExtraFields, see
+// ExtraFieldsModel. In strict mode an *UnknownFieldsError is returned for them,
+// and a *ValidationError for enum fields with unknown values.
 func (a *JsonApi) All(data interface{}, params url.Values) error {
 	completePath := a.path
 	if params != nil {
@@ -194,6 +223,17 @@ func (a *JsonApi) All(data interface{}, params url.Values) error {
 		info.Printf("%T: %v\n", err, err)
 		return err
 	}
+	unknownFields, err := decodeAllExtraFields(rawPayloads, data)
+	if err != nil {
+		info.Printf("%T: %v\n", err, err)
+		return err
+	}
+	if a.Strict() && len(unknownFields) != 0 {
+		return &UnknownFieldsError{Type: typeName(data), Fields: unknownFields}
+	}
+	if a.Strict() {
+		return unknownEnumValues(data)
+	}
 	return nil
 }
 
@@ -201,6 +241,8 @@ func (a *JsonApi) All(data interface{}, params url.Values) error {
 //
 // id is accepted as primitive data type or as type which implements
 // the fmt.Stringer interface.
+//
+// Attributes unknown to the resource are handled as described for All.
 func (a *JsonApi) Find(id interface{}, data interface{}, params url.Values) error {
 	// TODO: It's nice to build "templates" for Sprintf, but it's not comprehensible
 	findTemplate := fmt.Sprintf("%s/%%%%%%c", a.path)
@@ -241,11 +283,33 @@ func (a *JsonApi) Find(id interface{}, data interface{}, params url.Values) erro
 		info.Printf("%T: %v\n", err, err)
 		return err
 	}
+	unknownFields, err := decodeExtraFields(marshaled, data)
+	if err != nil {
+		info.Printf("%T: %v\n", err, err)
+		return err
+	}
+	if a.Strict() && len(unknownFields) != 0 {
+		return &UnknownFieldsError{Type: typeName(data), Fields: unknownFields}
+	}
+	if a.Strict() {
+		return unknownEnumValues(data)
+	}
 	return nil
 }
 
 // Create creates a new data entry at the API endpoint
+//
+// If data implements Validator, it is validated before sending. Enum fields
+// with unknown values are rejected with a ValidationError.
 func (a *JsonApi) Create(data CrudModel) error {
+	err := validate(data)
+	if err != nil {
+		return err
+	}
+	err = unknownEnumValues(data)
+	if err != nil {
+		return err
+	}
 	marshaledData, err := json.Marshal(&data)
 	if err != nil {
 		info.Printf("%T: %v\n", err, err)
@@ -296,7 +360,14 @@ func (a *JsonApi) Create(data CrudModel) error {
 }
 
 // Update updates the provided data at the API endpoint
+//
+// If data implements ExtraFieldsModel, its ExtraFields are sent as well. If
+// data implements Validator, it is validated before sending.
 func (a *JsonApi) Update(data CrudModel) error {
+	err := validate(data)
+	if err != nil {
+		return err
+	}
 	id := data.Id()
 	// TODO: It's nice to build "templates" for Sprintf, but it's not comprehensible
 	updateTemplate := fmt.Sprintf("%s/%%d", a.path)
@@ -305,6 +376,11 @@ func (a *JsonApi) Update(data CrudModel) error {
 		info.Printf("%T: %v\n", err, err)
 		return err
 	}
+	marshaledData, err = encodeExtraFields(marshaledData, data)
+	if err != nil {
+		info.Printf("%T: %v\n", err, err)
+		return err
+	}
 	requestPayload := &JsonApiPayload{
 		name:           strings.ToLower(data.Type()),
 		marshaledValue: marshaledData,
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -413,6 +414,27 @@ func TestJsonApiUpdate(t *testing.T) {
 		t.Fail()
 	}
 
+	// Patch update
+	testClient.setResponsePayload(http.StatusOK, nil, nil, "")
+
+	err = api.Update(NewPatch(&testData, "Data"))
+
+	if err != nil {
+		t.Logf("Expected no error, got: %v\n", err)
+		t.Fail()
+	}
+
+	requestBodyBytes = panicErr(ioutil.ReadAll(testClient.testRequest.Body)).([]byte)
+	expectedBytes = []byte(`{"testpayload":{"Data":"foobar"}}`)
+	if !bytes.Equal(expectedBytes, requestBodyBytes) {
+		t.Logf("Expected request body to equal '%s', got '%s'\n", string(expectedBytes), string(requestBodyBytes))
+		t.Fail()
+	}
+	if !strings.HasSuffix(testClient.testRequest.URL.Path, "/12") {
+		t.Logf("Expected request path to end with '/12', got '%s'\n", testClient.testRequest.URL.Path)
+		t.Fail()
+	}
+
 	// Failing update
 	body := &ErrorPayload{Message: "FAIL"}
 	bodyBytes := panicErr(json.Marshal(&body)).([]byte)
@@ -19,6 +19,10 @@ type DayEntryEndpoint struct {
 }
 
 func (d DayEntryEndpoint) All(data interface{}, params url.Values) error {
+	dayEntries, ok := data.(*[]*harvest.DayEntry)
+	if !ok {
+		return fmt.Errorf("Expected data to be of type *[]*harvest.DayEntry, got %T", data)
+	}
 	timeframe, err := harvest.TimeframeFromQuery(params)
 	if err != nil {
 		return fmt.Errorf("Error while parsing timeframe: %v", err)
@@ -66,7 +70,7 @@ func (d DayEntryEndpoint) All(data interface{}, params url.Values) error {
 			entries = append(entries, entry)
 		}
 	}
-	*(data.(*[]*harvest.DayEntry)) = entries
+	*dayEntries = entries
 	return nil
 }
 
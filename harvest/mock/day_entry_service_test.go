@@ -4,7 +4,6 @@ import (
 	"net/url"
 	"reflect"
 	"testing"
-	"time"
 
 	"github.com/mitch000001/go-harvest/harvest"
 )
@@ -12,8 +11,8 @@ import (
 func TestNewDayEntryService(t *testing.T) {
 	endpoint := DayEntryEndpoint{
 		Entries: []*harvest.DayEntry{
-			&harvest.DayEntry{ID: 1, UserId: 1, Hours: 8, TaskId: 2, SpentAt: harvest.Date(2015, 1, 2, time.UTC)},
-			&harvest.DayEntry{ID: 1, UserId: 2, Hours: 8, TaskId: 2, SpentAt: harvest.Date(2015, 1, 3, time.UTC)},
+			&harvest.DayEntry{ID: 1, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 2, SpentAt: harvest.Date(2015, 1, 2)},
+			&harvest.DayEntry{ID: 1, UserId: 2, Hours: 8 * harvest.Hour, TaskId: 2, SpentAt: harvest.Date(2015, 1, 3)},
 		},
 		UserId: 1,
 	}
@@ -26,7 +25,7 @@ func TestNewDayEntryService(t *testing.T) {
 	}
 
 	var entries []*harvest.DayEntry
-	timeframe := harvest.NewTimeframe(2015, 1, 1, 2015, 4, 1, time.UTC)
+	timeframe := harvest.NewTimeframe(2015, 1, 1, 2015, 4, 1)
 	var params harvest.Params
 
 	err := dayEntryService.All(&entries, params.ForTimeframe(timeframe).Values())
@@ -37,7 +36,7 @@ func TestNewDayEntryService(t *testing.T) {
 	}
 
 	expectedEntries := []*harvest.DayEntry{
-		&harvest.DayEntry{ID: 1, UserId: 1, Hours: 8, TaskId: 2, SpentAt: harvest.Date(2015, 1, 2, time.UTC)},
+		&harvest.DayEntry{ID: 1, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 2, SpentAt: harvest.Date(2015, 1, 2)},
 	}
 
 	if !reflect.DeepEqual(expectedEntries, entries) {
@@ -49,16 +48,16 @@ func TestNewDayEntryService(t *testing.T) {
 func TestDayEntryEndpointAll(t *testing.T) {
 	endpoint := DayEntryEndpoint{
 		Entries: []*harvest.DayEntry{
-			&harvest.DayEntry{ID: 1, UserId: 1, Hours: 8, TaskId: 2, SpentAt: harvest.Date(2015, 1, 1, time.UTC)},
-			&harvest.DayEntry{ID: 2, UserId: 1, Hours: 8, TaskId: 2, SpentAt: harvest.Date(2015, 2, 1, time.UTC)},
-			&harvest.DayEntry{ID: 3, UserId: 1, Hours: 8, TaskId: 5, SpentAt: harvest.Date(2015, 1, 19, time.UTC)},
-			&harvest.DayEntry{ID: 4, UserId: 1, Hours: 8, TaskId: 7, SpentAt: harvest.Date(2015, 1, 20, time.UTC)},
-			&harvest.DayEntry{ID: 5, UserId: 1, Hours: 8, TaskId: 9, SpentAt: harvest.Date(2015, 1, 21, time.UTC)},
-			&harvest.DayEntry{ID: 11, UserId: 2, Hours: 8, TaskId: 2, SpentAt: harvest.Date(2015, 1, 1, time.UTC)},
-			&harvest.DayEntry{ID: 12, UserId: 2, Hours: 8, TaskId: 2, SpentAt: harvest.Date(2015, 2, 1, time.UTC)},
-			&harvest.DayEntry{ID: 13, UserId: 2, Hours: 8, TaskId: 5, SpentAt: harvest.Date(2015, 1, 19, time.UTC)},
-			&harvest.DayEntry{ID: 14, UserId: 2, Hours: 8, TaskId: 7, SpentAt: harvest.Date(2015, 1, 20, time.UTC)},
-			&harvest.DayEntry{ID: 15, UserId: 2, Hours: 8, TaskId: 9, SpentAt: harvest.Date(2015, 1, 21, time.UTC)},
+			&harvest.DayEntry{ID: 1, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 2, SpentAt: harvest.Date(2015, 1, 1)},
+			&harvest.DayEntry{ID: 2, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 2, SpentAt: harvest.Date(2015, 2, 1)},
+			&harvest.DayEntry{ID: 3, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 5, SpentAt: harvest.Date(2015, 1, 19)},
+			&harvest.DayEntry{ID: 4, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 7, SpentAt: harvest.Date(2015, 1, 20)},
+			&harvest.DayEntry{ID: 5, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 9, SpentAt: harvest.Date(2015, 1, 21)},
+			&harvest.DayEntry{ID: 11, UserId: 2, Hours: 8 * harvest.Hour, TaskId: 2, SpentAt: harvest.Date(2015, 1, 1)},
+			&harvest.DayEntry{ID: 12, UserId: 2, Hours: 8 * harvest.Hour, TaskId: 2, SpentAt: harvest.Date(2015, 2, 1)},
+			&harvest.DayEntry{ID: 13, UserId: 2, Hours: 8 * harvest.Hour, TaskId: 5, SpentAt: harvest.Date(2015, 1, 19)},
+			&harvest.DayEntry{ID: 14, UserId: 2, Hours: 8 * harvest.Hour, TaskId: 7, SpentAt: harvest.Date(2015, 1, 20)},
+			&harvest.DayEntry{ID: 15, UserId: 2, Hours: 8 * harvest.Hour, TaskId: 9, SpentAt: harvest.Date(2015, 1, 21)},
 		},
 		BillableTasks: []int{2, 5},
 		UserId:        1,
@@ -66,7 +65,7 @@ func TestDayEntryEndpointAll(t *testing.T) {
 
 	var entries []*harvest.DayEntry
 	var params harvest.Params
-	timeframe := harvest.NewTimeframe(2015, 1, 1, 2015, 4, 1, time.UTC)
+	timeframe := harvest.NewTimeframe(2015, 1, 1, 2015, 4, 1)
 
 	err := endpoint.All(&entries, params.ForTimeframe(timeframe).Values())
 
@@ -76,11 +75,11 @@ func TestDayEntryEndpointAll(t *testing.T) {
 	}
 
 	expectedEntries := []*harvest.DayEntry{
-		&harvest.DayEntry{ID: 1, UserId: 1, Hours: 8, TaskId: 2, SpentAt: harvest.Date(2015, 1, 1, time.UTC)},
-		&harvest.DayEntry{ID: 2, UserId: 1, Hours: 8, TaskId: 2, SpentAt: harvest.Date(2015, 2, 1, time.UTC)},
-		&harvest.DayEntry{ID: 3, UserId: 1, Hours: 8, TaskId: 5, SpentAt: harvest.Date(2015, 1, 19, time.UTC)},
-		&harvest.DayEntry{ID: 4, UserId: 1, Hours: 8, TaskId: 7, SpentAt: harvest.Date(2015, 1, 20, time.UTC)},
-		&harvest.DayEntry{ID: 5, UserId: 1, Hours: 8, TaskId: 9, SpentAt: harvest.Date(2015, 1, 21, time.UTC)},
+		&harvest.DayEntry{ID: 1, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 2, SpentAt: harvest.Date(2015, 1, 1)},
+		&harvest.DayEntry{ID: 2, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 2, SpentAt: harvest.Date(2015, 2, 1)},
+		&harvest.DayEntry{ID: 3, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 5, SpentAt: harvest.Date(2015, 1, 19)},
+		&harvest.DayEntry{ID: 4, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 7, SpentAt: harvest.Date(2015, 1, 20)},
+		&harvest.DayEntry{ID: 5, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 9, SpentAt: harvest.Date(2015, 1, 21)},
 	}
 
 	if !reflect.DeepEqual(expectedEntries, entries) {
@@ -89,7 +88,7 @@ func TestDayEntryEndpointAll(t *testing.T) {
 	}
 
 	// Proper filtering for timeframes
-	timeframe = harvest.NewTimeframe(2015, 1, 1, 2015, 1, 25, time.UTC)
+	timeframe = harvest.NewTimeframe(2015, 1, 1, 2015, 1, 25)
 	params = harvest.Params{}
 
 	err = endpoint.All(&entries, params.ForTimeframe(timeframe).Values())
@@ -100,10 +99,10 @@ func TestDayEntryEndpointAll(t *testing.T) {
 	}
 
 	expectedEntries = []*harvest.DayEntry{
-		&harvest.DayEntry{ID: 1, UserId: 1, Hours: 8, TaskId: 2, SpentAt: harvest.Date(2015, 1, 1, time.UTC)},
-		&harvest.DayEntry{ID: 3, UserId: 1, Hours: 8, TaskId: 5, SpentAt: harvest.Date(2015, 1, 19, time.UTC)},
-		&harvest.DayEntry{ID: 4, UserId: 1, Hours: 8, TaskId: 7, SpentAt: harvest.Date(2015, 1, 20, time.UTC)},
-		&harvest.DayEntry{ID: 5, UserId: 1, Hours: 8, TaskId: 9, SpentAt: harvest.Date(2015, 1, 21, time.UTC)},
+		&harvest.DayEntry{ID: 1, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 2, SpentAt: harvest.Date(2015, 1, 1)},
+		&harvest.DayEntry{ID: 3, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 5, SpentAt: harvest.Date(2015, 1, 19)},
+		&harvest.DayEntry{ID: 4, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 7, SpentAt: harvest.Date(2015, 1, 20)},
+		&harvest.DayEntry{ID: 5, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 9, SpentAt: harvest.Date(2015, 1, 21)},
 	}
 
 	if !reflect.DeepEqual(expectedEntries, entries) {
@@ -122,8 +121,8 @@ func TestDayEntryEndpointAll(t *testing.T) {
 	}
 
 	expectedEntries = []*harvest.DayEntry{
-		&harvest.DayEntry{ID: 1, UserId: 1, Hours: 8, TaskId: 2, SpentAt: harvest.Date(2015, 1, 1, time.UTC)},
-		&harvest.DayEntry{ID: 3, UserId: 1, Hours: 8, TaskId: 5, SpentAt: harvest.Date(2015, 1, 19, time.UTC)},
+		&harvest.DayEntry{ID: 1, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 2, SpentAt: harvest.Date(2015, 1, 1)},
+		&harvest.DayEntry{ID: 3, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 5, SpentAt: harvest.Date(2015, 1, 19)},
 	}
 
 	if !reflect.DeepEqual(expectedEntries, entries) {
@@ -142,8 +141,8 @@ func TestDayEntryEndpointAll(t *testing.T) {
 	}
 
 	expectedEntries = []*harvest.DayEntry{
-		&harvest.DayEntry{ID: 4, UserId: 1, Hours: 8, TaskId: 7, SpentAt: harvest.Date(2015, 1, 20, time.UTC)},
-		&harvest.DayEntry{ID: 5, UserId: 1, Hours: 8, TaskId: 9, SpentAt: harvest.Date(2015, 1, 21, time.UTC)},
+		&harvest.DayEntry{ID: 4, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 7, SpentAt: harvest.Date(2015, 1, 20)},
+		&harvest.DayEntry{ID: 5, UserId: 1, Hours: 8 * harvest.Hour, TaskId: 9, SpentAt: harvest.Date(2015, 1, 21)},
 	}
 
 	if !reflect.DeepEqual(expectedEntries, entries) {
@@ -14,7 +14,7 @@ type ProjectService struct {
 func (p ProjectService) All(projects *[]*harvest.Project, params harvest.Params) error {
 	if params != nil {
 		if updatedSince := params.Get("updated_since"); updatedSince != "" {
-			t, err := time.Parse("2006-01-02 15:04:05.999999999 -0700 MST", updatedSince)
+			t, err := time.Parse(harvest.UpdatedSinceFormat, updatedSince)
 			if err != nil {
 				return fmt.Errorf("Error while parsing updated since: %v", err)
 			}
@@ -1,7 +1,12 @@
 package mock
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
 	"net/url"
 	"time"
 
@@ -10,25 +15,39 @@ import (
 
 func NewUserService(userService *UserEndpoint) *harvest.UserService {
 	var service *harvest.UserService
-	service = harvest.NewUserService(userService, userService)
+	service = harvest.NewUserService(userService, userService, userService)
 	return service
 }
 
 type UserEndpoint struct {
 	Users            []*harvest.User
 	DayEntryEndpoint DayEntryEndpoint
+	// PasswordResets contains the ids of all users a password reset was
+	// requested for
+	PasswordResets []int
 }
 
-func (u *UserEndpoint) All(users interface{}, params url.Values) error {
-	*(users.(*[]*harvest.User)) = u.Users
+func (u *UserEndpoint) All(data interface{}, params url.Values) error {
+	users, ok := data.(*[]*harvest.User)
+	if !ok {
+		return fmt.Errorf("Expected data to be of type *[]*harvest.User, got %T", data)
+	}
+	*users = u.Users
 	return nil
 }
 
-func (u *UserEndpoint) Find(id interface{}, user interface{}, params url.Values) error {
-	ID := id.(int)
+func (u *UserEndpoint) Find(id interface{}, data interface{}, params url.Values) error {
+	ID, ok := id.(int)
+	if !ok {
+		return fmt.Errorf("Expected id to be of type int, got %T", id)
+	}
+	user, ok := data.(*harvest.User)
+	if !ok {
+		return fmt.Errorf("Expected data to be of type *harvest.User, got %T", data)
+	}
 	for _, u := range u.Users {
 		if ID == u.ID {
-			*(user.(*harvest.User)) = *u
+			*user = *u
 			return nil
 		}
 	}
@@ -36,7 +55,10 @@ func (u *UserEndpoint) Find(id interface{}, user interface{}, params url.Values)
 }
 
 func (u *UserEndpoint) Create(model harvest.CrudModel) error {
-	user := model.(*harvest.User)
+	user, ok := model.(*harvest.User)
+	if !ok {
+		return fmt.Errorf("Expected model to be of type *harvest.User, got %T", model)
+	}
 	u.Users = append(u.Users, user)
 	return nil
 }
@@ -44,7 +66,22 @@ func (u *UserEndpoint) Create(model harvest.CrudModel) error {
 func (u *UserEndpoint) Update(model harvest.CrudModel) error {
 	for _, user := range u.Users {
 		if model.Id() == user.ID {
-			*user = *model.(*harvest.User)
+			if patch, ok := model.(*harvest.Patch); ok {
+				marshaledPatch, err := json.Marshal(patch)
+				if err != nil {
+					return err
+				}
+				err = json.Unmarshal(marshaledPatch, user)
+				if err != nil {
+					return err
+				}
+			} else {
+				updatedUser, ok := model.(*harvest.User)
+				if !ok {
+					return fmt.Errorf("Expected model to be of type *harvest.User, got %T", model)
+				}
+				*user = *updatedUser
+			}
 			user.UpdatedAt = time.Now().In(time.UTC)
 		}
 	}
@@ -87,3 +124,18 @@ func (u *UserEndpoint) CrudEndpoint(path string) harvest.CrudEndpoint {
 	dayEntryEndpoint.UserId = userId
 	return dayEntryEndpoint
 }
+
+func (u *UserEndpoint) Process(method string, path string, body io.Reader) (*http.Response, error) {
+	var userId int
+	_, err := fmt.Sscanf(path, "users/%d/reset_password", &userId)
+	if method != "POST" || err != nil {
+		return nil, fmt.Errorf("Unsupported request: %s %s", method, path)
+	}
+	u.PasswordResets = append(u.PasswordResets, userId)
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json; charset=utf-8"}},
+		Body:       ioutil.NopCloser(bytes.NewReader([]byte("{}"))),
+	}
+	return response, nil
+}
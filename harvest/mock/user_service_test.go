@@ -16,7 +16,7 @@ func TestNewUserService(t *testing.T) {
 		},
 		DayEntryEndpoint: DayEntryEndpoint{
 			Entries: []*harvest.DayEntry{
-				&harvest.DayEntry{ID: 3, UserId: 1, TaskId: 3, Hours: 8, SpentAt: harvest.Date(2015, 1, 1, time.UTC)},
+				&harvest.DayEntry{ID: 3, UserId: 1, TaskId: 3, Hours: 8 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 1)},
 			},
 		},
 	}
@@ -54,9 +54,9 @@ func TestNewUserService(t *testing.T) {
 
 	var actualEntries []*harvest.DayEntry
 	expectedEntries := []*harvest.DayEntry{
-		&harvest.DayEntry{ID: 3, UserId: 1, TaskId: 3, Hours: 8, SpentAt: harvest.Date(2015, 1, 1, time.UTC)},
+		&harvest.DayEntry{ID: 3, UserId: 1, TaskId: 3, Hours: 8 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 1)},
 	}
-	timeframe := harvest.NewTimeframe(2015, 1, 1, 2015, 4, 1, time.UTC)
+	timeframe := harvest.NewTimeframe(2015, 1, 1, 2015, 4, 1)
 	var params harvest.Params
 
 	err = dayEntryService.All(&actualEntries, params.ForTimeframe(timeframe).Values())
@@ -181,6 +181,26 @@ func TestUserEndpointUpdate(t *testing.T) {
 		t.Logf("Expected user2.Firstname to equal 'Charlie', got %q\n", userToUpdate.FirstName)
 		t.Fail()
 	}
+
+	// Patch
+	mockUserEndpoint.Users[1].IsActive = true
+	userToPatch := &harvest.User{ID: 2, FirstName: "Kevin", IsActive: false}
+
+	err = mockUserEndpoint.Update(harvest.NewPatch(userToPatch, "is_active"))
+
+	if err != nil {
+		t.Logf("Expected no error, got %T:%v\n", err, err)
+		t.Fail()
+	}
+
+	if mockUserEndpoint.Users[1].IsActive {
+		t.Logf("Expected user2.IsActive to be false, got true\n")
+		t.Fail()
+	}
+	if mockUserEndpoint.Users[1].FirstName != "Charlie" {
+		t.Logf("Expected user2.Firstname to equal 'Charlie', got %q\n", mockUserEndpoint.Users[1].FirstName)
+		t.Fail()
+	}
 }
 
 func TestUserEndpointDelete(t *testing.T) {
@@ -285,3 +305,60 @@ func TestUserEndpointCrudEndpoint(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestUserEndpointProcess(t *testing.T) {
+	mockUserEndpoint := UserEndpoint{
+		Users: []*harvest.User{
+			&harvest.User{ID: 1},
+		},
+	}
+
+	userService := NewUserService(&mockUserEndpoint)
+
+	err := userService.ResetPassword(mockUserEndpoint.Users[0])
+
+	if err != nil {
+		t.Logf("Expected no error, got %T:%v\n", err, err)
+		t.Fail()
+	}
+
+	expectedResets := []int{1}
+
+	if !reflect.DeepEqual(expectedResets, mockUserEndpoint.PasswordResets) {
+		t.Logf("Expected password resets to equal %v, got %v\n", expectedResets, mockUserEndpoint.PasswordResets)
+		t.Fail()
+	}
+
+	// Unsupported request
+	_, err = mockUserEndpoint.Process("GET", "users/1/entries", nil)
+
+	if err == nil {
+		t.Logf("Expected error, got nil\n")
+		t.Fail()
+	}
+}
+
+func TestUserEndpointWrongType(t *testing.T) {
+	mockUserEndpoint := UserEndpoint{}
+
+	err := mockUserEndpoint.All(&[]*harvest.Project{}, nil)
+
+	if err == nil {
+		t.Logf("Expected error, got nil\n")
+		t.Fail()
+	}
+
+	err = mockUserEndpoint.Find(1, &harvest.Project{}, nil)
+
+	if err == nil {
+		t.Logf("Expected error, got nil\n")
+		t.Fail()
+	}
+
+	err = mockUserEndpoint.Create(&harvest.Project{})
+
+	if err == nil {
+		t.Logf("Expected error, got nil\n")
+		t.Fail()
+	}
+}
@@ -0,0 +1,353 @@
+package harvest
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// moneyPlaces defines the number of decimal places Money is exact to
+const moneyPlaces = 4
+
+var moneyScale = big.NewInt(10000)
+
+// RoundingMode defines how values are rounded to the precision of Money
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds to the nearest value, ties to the even value
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds to the nearest value, ties away from zero
+	RoundHalfUp
+	// RoundDown rounds towards zero
+	RoundDown
+	// RoundUp rounds away from zero
+	RoundUp
+)
+
+// Money is a fixed point monetary amount with four decimal places together
+// with its ISO currency code. The currency may be empty if it is unknown,
+// e.g. for rates which are billed in the currency of the client. See
+// Client.Currency and Money.In.
+//
+// The zero value is an amount of zero without currency. As Money is a struct,
+// the omitempty JSON option has no effect on it. Optional amounts, which must
+// not be sent to the API unless set, are therefore modeled as *Money.
+type Money struct {
+	units    int64 // amount in 1/10000 of the currency unit
+	currency string
+}
+
+// NewMoney parses the decimal amount and returns it as Money in the given
+// currency. Amounts with more than four decimal places are rounded half even.
+func NewMoney(amount string, currency string) (Money, error) {
+	rat, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok {
+		return Money{}, fmt.Errorf("Malformed amount: %q", amount)
+	}
+	units, err := ratToUnits(rat, RoundHalfEven)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{units: units, currency: CurrencyCode(currency)}, nil
+}
+
+// MoneyFromFloat returns the amount as Money in the given currency. The
+// amount is rounded half even to four decimal places.
+func MoneyFromFloat(amount float64, currency string) Money {
+	rat := new(big.Rat)
+	if rat.SetFloat64(amount) == nil {
+		return Money{currency: CurrencyCode(currency)}
+	}
+	units, _ := ratToUnits(rat, RoundHalfEven)
+	return Money{units: units, currency: CurrencyCode(currency)}
+}
+
+// Ptr returns a pointer to a copy of m, e.g. to set optional amounts like
+// Project.HourlyRate
+func (m Money) Ptr() *Money {
+	return &m
+}
+
+// Value returns the amount m points to, or the zero value if m is nil
+func (m *Money) Value() Money {
+	if m == nil {
+		return Money{}
+	}
+	return *m
+}
+
+// Currency returns the currency code of m
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// In returns the same amount in the given currency. Currencies as returned by
+// the API, e.g. "Euro - EUR", are reduced to their ISO code.
+func (m Money) In(currency string) Money {
+	return Money{units: m.units, currency: CurrencyCode(currency)}
+}
+
+// IsZero returns true if the amount is zero, regardless of the currency
+func (m Money) IsZero() bool {
+	return m.units == 0
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of the amount
+func (m Money) Sign() int {
+	switch {
+	case m.units < 0:
+		return -1
+	case m.units > 0:
+		return 1
+	}
+	return 0
+}
+
+// Neg returns the negated amount
+func (m Money) Neg() Money {
+	return Money{units: -m.units, currency: m.currency}
+}
+
+// Add returns the sum of m and other.
+//
+// It returns an error if both have a currency and the currencies differ.
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.commonCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{units: m.units + other.units, currency: currency}, nil
+}
+
+// Sub returns the difference of m and other.
+//
+// It returns an error if both have a currency and the currencies differ.
+func (m Money) Sub(other Money) (Money, error) {
+	return m.Add(other.Neg())
+}
+
+// Cmp compares m and other and returns -1, 0 or +1 if m is less than, equal
+// to or greater than other.
+//
+// It returns an error if both have a currency and the currencies differ.
+func (m Money) Cmp(other Money) (int, error) {
+	difference, err := m.Sub(other)
+	if err != nil {
+		return 0, err
+	}
+	return difference.Sign(), nil
+}
+
+// Mul returns m multiplied by factor, rounded with the given mode. The
+// product is computed exactly before rounding.
+func (m Money) Mul(factor float64, mode RoundingMode) Money {
+	rat := new(big.Rat)
+	if rat.SetFloat64(factor) == nil {
+		return Money{currency: m.currency}
+	}
+	rat.Mul(rat, m.rat())
+	units, _ := ratToUnits(rat, mode)
+	return Money{units: units, currency: m.currency}
+}
+
+// MulHours returns the amount for hours at the hourly rate m, rounded once to
+// the given number of decimal places with the given mode. The product is
+// computed exactly from the seconds of hours.
+func (m Money) MulHours(hours Hours, places int, mode RoundingMode) Money {
+	if places > moneyPlaces || places < 0 {
+		places = moneyPlaces
+	}
+	rat := new(big.Rat).Mul(m.rat(), big.NewRat(int64(hours), int64(Hour)))
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(places)), nil)
+	rat.Mul(rat, new(big.Rat).SetInt(factor))
+	rounded := roundRat(rat, mode)
+	rounded.Mul(rounded, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(moneyPlaces-places)), nil))
+	return Money{units: rounded.Int64(), currency: m.currency}
+}
+
+// Div returns m divided by divisor, rounded with the given mode.
+//
+// It returns an error if the divisor is zero.
+func (m Money) Div(divisor float64, mode RoundingMode) (Money, error) {
+	rat := new(big.Rat)
+	if divisor == 0 || rat.SetFloat64(divisor) == nil {
+		return Money{}, fmt.Errorf("Invalid divisor: %v", divisor)
+	}
+	rat.Quo(m.rat(), rat)
+	units, err := ratToUnits(rat, mode)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{units: units, currency: m.currency}, nil
+}
+
+// Round rounds m to the given number of decimal places with the given mode.
+// places must be between 0 and 4.
+func (m Money) Round(places int, mode RoundingMode) Money {
+	if places >= moneyPlaces || places < 0 {
+		return m
+	}
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(moneyPlaces-places)), nil)
+	rat := new(big.Rat).SetFrac(big.NewInt(m.units), factor)
+	rounded := roundRat(rat, mode)
+	return Money{units: rounded.Mul(rounded, factor).Int64(), currency: m.currency}
+}
+
+// Float64 returns the nearest float64 value of the amount. It is meant for
+// display and statistics only, use the arithmetic methods for calculations.
+func (m Money) Float64() float64 {
+	f, _ := m.rat().Float64()
+	return f
+}
+
+// Decimal returns the amount as decimal string with at least two decimal
+// places, e.g. "1234.50"
+func (m Money) Decimal() string {
+	units := m.units
+	sign := ""
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+	scale := moneyScale.Int64()
+	fraction := fmt.Sprintf("%04d", units%scale)
+	fraction = strings.TrimRight(fraction, "0")
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+	return fmt.Sprintf("%s%d.%s", sign, units/scale, fraction)
+}
+
+// Format formats the amount rounded half up to two decimal places with the
+// given decimal symbol and thousands separator, e.g. "1.234,50". The currency
+// is not part of the result.
+func (m Money) Format(decimalSymbol DecimalSymbol, thousandsSeparator ThousandsSeparator) string {
+	if decimalSymbol == "" {
+		decimalSymbol = PeriodDS
+	}
+	cents := m.Round(2, RoundHalfUp).units / (moneyScale.Int64() / 100)
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%s%s%02d", sign, groupThousands(cents/100, thousandsSeparator), decimalSymbol, cents%100)
+}
+
+// String returns the decimal amount followed by the currency, if present
+func (m Money) String() string {
+	if m.currency == "" {
+		return m.Decimal()
+	}
+	return fmt.Sprintf("%s %s", m.Decimal(), m.currency)
+}
+
+// MarshalJSON marshals the amount as JSON number. The currency is not
+// marshaled, it is provided by the surrounding model.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.Decimal()), nil
+}
+
+// UnmarshalJSON accepts JSON numbers, strings containing a decimal number
+// and null. Empty strings and null are treated as zero.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	amount := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		var err error
+		amount, err = strconv.Unquote(amount)
+		if err != nil {
+			return err
+		}
+	}
+	if amount == "" || amount == "null" {
+		*m = Money{currency: m.currency}
+		return nil
+	}
+	money, err := NewMoney(amount, m.currency)
+	if err != nil {
+		return err
+	}
+	*m = money
+	return nil
+}
+
+// SumMoney returns the sum of all values.
+//
+// It returns an error if any two values have different currencies.
+func SumMoney(values ...Money) (Money, error) {
+	var sum Money
+	var err error
+	for _, v := range values {
+		sum, err = sum.Add(v)
+		if err != nil {
+			return Money{}, err
+		}
+	}
+	return sum, nil
+}
+
+// CurrencyCode returns the ISO code of currency. Currencies as returned by
+// the API, e.g. "Euro - EUR", are reduced to their ISO code, other values are
+// returned unchanged.
+func CurrencyCode(currency string) string {
+	if i := strings.LastIndex(currency, " - "); i != -1 {
+		return strings.TrimSpace(currency[i+len(" - "):])
+	}
+	return currency
+}
+
+func (m Money) commonCurrency(other Money) (string, error) {
+	switch {
+	case m.currency == "":
+		return other.currency, nil
+	case other.currency == "" || m.currency == other.currency:
+		return m.currency, nil
+	}
+	return "", fmt.Errorf("Currency mismatch: %s and %s", m.currency, other.currency)
+}
+
+func (m Money) rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(m.units), moneyScale)
+}
+
+// ratToUnits converts the amount r into 1/10000 units using the given mode
+func ratToUnits(r *big.Rat, mode RoundingMode) (int64, error) {
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(moneyScale))
+	units := roundRat(scaled, mode)
+	if !units.IsInt64() {
+		return 0, fmt.Errorf("Amount out of range: %s", r.FloatString(moneyPlaces))
+	}
+	return units.Int64(), nil
+}
+
+// roundRat rounds r to an integer using the given mode
+func roundRat(r *big.Rat, mode RoundingMode) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+	// remainder has the sign of r, so away from zero is the direction of r
+	awayFromZero := big.NewInt(int64(r.Sign()))
+	twiceRemainder := new(big.Int).Abs(remainder)
+	twiceRemainder.Lsh(twiceRemainder, 1)
+	half := twiceRemainder.Cmp(r.Denom())
+	roundAway := false
+	switch mode {
+	case RoundUp:
+		roundAway = true
+	case RoundDown:
+		roundAway = false
+	case RoundHalfUp:
+		roundAway = half >= 0
+	case RoundHalfEven:
+		roundAway = half > 0 || (half == 0 && quotient.Bit(0) == 1)
+	}
+	if roundAway {
+		quotient.Add(quotient, awayFromZero)
+	}
+	return quotient
+}
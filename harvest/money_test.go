@@ -0,0 +1,354 @@
+package harvest
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func mustMoney(t *testing.T, amount string, currency string) Money {
+	money, err := NewMoney(amount, currency)
+	if err != nil {
+		t.Fatalf("Expected no error, got %T: %v\n", err, err)
+	}
+	return money
+}
+
+func TestNewMoney(t *testing.T) {
+	var tests = []struct {
+		amount      string
+		currency    string
+		expected    string
+		expectError bool
+	}{
+		{"100", "EUR", "100.00 EUR", false},
+		{"0.1", "", "0.10", false},
+		{"-12.3456", "USD", "-12.3456 USD", false},
+		{"1.23455", "", "1.2346", false},
+		{"1.23445", "", "1.2344", false},
+		{" 7.5 ", "United States Dollar - USD", "7.50 USD", false},
+		{"abc", "", "", true},
+		{"", "", "", true},
+	}
+	for _, test := range tests {
+		money, err := NewMoney(test.amount, test.currency)
+
+		if test.expectError {
+			if err == nil {
+				t.Logf("Expected error for %q, got nil\n", test.amount)
+				t.Fail()
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Logf("Expected no error for %q, got %T: %v\n", test.amount, err, err)
+			t.Fail()
+		}
+
+		if money.String() != test.expected {
+			t.Logf("Expected money to equal %q, got %q\n", test.expected, money.String())
+			t.Fail()
+		}
+	}
+}
+
+func TestMoneyFromFloat(t *testing.T) {
+	money := MoneyFromFloat(0.1, "EUR")
+
+	sum, _ := SumMoney(money, money, money)
+
+	if sum.String() != "0.30 EUR" {
+		t.Logf("Expected sum to equal %q, got %q\n", "0.30 EUR", sum.String())
+		t.Fail()
+	}
+}
+
+func TestMoneyAdd(t *testing.T) {
+	euro := mustMoney(t, "10.50", "EUR")
+
+	sum, err := euro.Add(mustMoney(t, "0.25", ""))
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if sum.String() != "10.75 EUR" {
+		t.Logf("Expected sum to equal %q, got %q\n", "10.75 EUR", sum.String())
+		t.Fail()
+	}
+
+	// different currencies
+	_, err = euro.Add(mustMoney(t, "1", "USD"))
+
+	if err == nil {
+		t.Logf("Expected error, got nil\n")
+		t.Fail()
+	}
+
+	difference, err := euro.Sub(mustMoney(t, "20", "EUR"))
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if difference.String() != "-9.50 EUR" {
+		t.Logf("Expected difference to equal %q, got %q\n", "-9.50 EUR", difference.String())
+		t.Fail()
+	}
+
+	cmp, _ := difference.Cmp(euro)
+
+	if cmp != -1 {
+		t.Logf("Expected %v to be less than %v\n", difference, euro)
+		t.Fail()
+	}
+}
+
+func TestMoneyMulAndDiv(t *testing.T) {
+	rate := mustMoney(t, "85", "EUR")
+
+	product := rate.Mul(1.1, RoundHalfEven)
+
+	if product.String() != "93.50 EUR" {
+		t.Logf("Expected product to equal %q, got %q\n", "93.50 EUR", product.String())
+		t.Fail()
+	}
+
+	quotient, err := mustMoney(t, "10", "").Div(3, RoundDown)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if quotient.Decimal() != "3.3333" {
+		t.Logf("Expected quotient to equal %q, got %q\n", "3.3333", quotient.Decimal())
+		t.Fail()
+	}
+
+	_, err = rate.Div(0, RoundHalfEven)
+
+	if err == nil {
+		t.Logf("Expected error, got nil\n")
+		t.Fail()
+	}
+}
+
+func TestMoneyRound(t *testing.T) {
+	var tests = []struct {
+		amount   string
+		places   int
+		mode     RoundingMode
+		expected string
+	}{
+		{"2.345", 2, RoundHalfEven, "2.34"},
+		{"2.355", 2, RoundHalfEven, "2.36"},
+		{"2.345", 2, RoundHalfUp, "2.35"},
+		{"-2.345", 2, RoundHalfUp, "-2.35"},
+		{"2.349", 2, RoundDown, "2.34"},
+		{"-2.341", 2, RoundDown, "-2.34"},
+		{"2.341", 2, RoundUp, "2.35"},
+		{"-2.341", 2, RoundUp, "-2.35"},
+		{"12.5", 0, RoundHalfEven, "12.00"},
+		{"1.2345", 4, RoundUp, "1.2345"},
+	}
+	for _, test := range tests {
+		rounded := mustMoney(t, test.amount, "").Round(test.places, test.mode)
+
+		if rounded.Decimal() != test.expected {
+			t.Logf("Expected %s rounded with mode %d to equal %q, got %q\n", test.amount, test.mode, test.expected, rounded.Decimal())
+			t.Fail()
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var tests = []struct {
+		data     string
+		expected string
+	}{
+		{`100.5`, "100.50"},
+		{`"100.0"`, "100.00"},
+		{`""`, "0.00"},
+		{`null`, "0.00"},
+		{`0.0001`, "0.0001"},
+	}
+	for _, test := range tests {
+		var money Money
+		err := json.Unmarshal([]byte(test.data), &money)
+
+		if err != nil {
+			t.Logf("Expected no error for %s, got %T: %v\n", test.data, err, err)
+			t.Fail()
+		}
+
+		if money.Decimal() != test.expected {
+			t.Logf("Expected %s to unmarshal to %q, got %q\n", test.data, test.expected, money.Decimal())
+			t.Fail()
+		}
+	}
+
+	marshaled, err := json.Marshal(mustMoney(t, "12.5", "EUR"))
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if string(marshaled) != "12.50" {
+		t.Logf("Expected money to marshal to %q, got %q\n", "12.50", marshaled)
+		t.Fail()
+	}
+
+	err = json.Unmarshal([]byte(`"abc"`), new(Money))
+
+	if err == nil {
+		t.Logf("Expected error, got nil\n")
+		t.Fail()
+	}
+}
+
+func TestInvoiceUnmarshalJSONSetsCurrency(t *testing.T) {
+	data := `{"id":1,"amount":"100.0","due-amount":40.5,"currency":"Euro - EUR"}`
+
+	var invoice Invoice
+	err := json.Unmarshal([]byte(data), &invoice)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %T: %v\n", err, err)
+	}
+
+	if invoice.Amount.String() != "100.00 EUR" {
+		t.Logf("Expected amount to equal %q, got %q\n", "100.00 EUR", invoice.Amount.String())
+		t.Fail()
+	}
+
+	if invoice.DueAmount.String() != "40.50 EUR" {
+		t.Logf("Expected due amount to equal %q, got %q\n", "40.50 EUR", invoice.DueAmount.String())
+		t.Fail()
+	}
+
+	if invoice.TaxAmount.Currency() != "EUR" {
+		t.Logf("Expected tax amount currency to equal %q, got %q\n", "EUR", invoice.TaxAmount.Currency())
+		t.Fail()
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	var tests = []struct {
+		amount             string
+		decimalSymbol      DecimalSymbol
+		thousandsSeparator ThousandsSeparator
+		expected           string
+	}{
+		{"1234.5", PeriodDS, CommaTS, "1,234.50"},
+		{"1234567.005", CommaDS, PeriodTS, "1.234.567,01"},
+		{"-0.5", "", "", "-0.50"},
+		{"999", CommaDS, Space, "999,00"},
+	}
+	for _, test := range tests {
+		formatted := mustMoney(t, test.amount, "").Format(test.decimalSymbol, test.thousandsSeparator)
+
+		if formatted != test.expected {
+			t.Logf("Expected %s to format to %q, got %q\n", test.amount, test.expected, formatted)
+			t.Fail()
+		}
+	}
+}
+
+func TestOptionalMoneyMarshalJSON(t *testing.T) {
+	var tests = []struct {
+		model    interface{}
+		expected map[string]interface{}
+	}{
+		{&Project{ID: 1}, map[string]interface{}{"id": 1.0}},
+		{&Project{ID: 1, HourlyRate: Money{}.Ptr()}, map[string]interface{}{"id": 1.0, "hourly_rate": 0.0}},
+		{&User{ID: 1}, map[string]interface{}{"id": 1.0, "is_project_manager": false, "can_see_rates": false, "can_create_projects": false, "can_create_invoices": false}},
+		{&User{ID: 1, CostRate: MoneyFromFloat(50, "").Ptr()}, map[string]interface{}{"id": 1.0, "cost_rate": 50.0, "is_project_manager": false, "can_see_rates": false, "can_create_projects": false, "can_create_invoices": false}},
+	}
+	for _, test := range tests {
+		marshaled, err := json.Marshal(test.model)
+
+		if err != nil {
+			t.Logf("Expected no error, got %T: %v\n", err, err)
+			t.Fail()
+			continue
+		}
+
+		var actual map[string]interface{}
+		json.Unmarshal(marshaled, &actual)
+		for _, field := range []string{"created_at", "updated_at", "starts-on", "ends-on", "hint_earliest_record_at", "hint_latest_record_at"} {
+			delete(actual, field)
+		}
+
+		if !reflect.DeepEqual(test.expected, actual) {
+			t.Logf("Expected %T to marshal to %v, got %s\n", test.model, test.expected, marshaled)
+			t.Fail()
+		}
+	}
+}
+
+func TestMoneyValue(t *testing.T) {
+	var rate *Money
+
+	if !rate.Value().IsZero() {
+		t.Logf("Expected nil to have zero value, got %s\n", rate.Value())
+		t.Fail()
+	}
+
+	rate = MoneyFromFloat(12.5, "EUR").Ptr()
+
+	if rate.Value() != MoneyFromFloat(12.5, "EUR") {
+		t.Logf("Expected value to equal 12.5 EUR, got %s\n", rate.Value())
+		t.Fail()
+	}
+}
+
+func TestMoneyMulHours(t *testing.T) {
+	var tests = []struct {
+		rate     string
+		hours    Hours
+		places   int
+		mode     RoundingMode
+		expected string
+	}{
+		{"100", 90 * Minute, 2, RoundHalfEven, "150.00"},
+		{"17.99", 1 * Second, 2, RoundHalfUp, "0.00"},
+		{"17.99", 1 * Second, 4, RoundHalfUp, "0.0050"},
+		{"0.01", 30 * Minute, 2, RoundHalfUp, "0.01"},
+		{"0.01", 30 * Minute, 2, RoundHalfEven, "0.00"},
+		{"-80", 45 * Minute, 2, RoundHalfEven, "-60.00"},
+	}
+	for _, test := range tests {
+		amount := mustMoney(t, test.rate, "EUR").MulHours(test.hours, test.places, test.mode)
+
+		expected := mustMoney(t, test.expected, "EUR")
+
+		if amount != expected {
+			t.Logf("Expected %s EUR for %s hours to equal %s, got %s\n", test.rate, test.hours, expected, amount)
+			t.Fail()
+		}
+	}
+}
+
+func TestCurrencyCode(t *testing.T) {
+	var tests = []struct {
+		currency string
+		expected string
+	}{
+		{"Euro - EUR", "EUR"},
+		{"USD", "USD"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		code := CurrencyCode(test.currency)
+
+		if code != test.expected {
+			t.Logf("Expected currency code of %q to equal %q, got %q\n", test.currency, test.expected, code)
+			t.Fail()
+		}
+	}
+}
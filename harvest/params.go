@@ -2,6 +2,7 @@ package harvest
 
 import (
 	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -10,6 +11,9 @@ import (
 // builder
 //
 // The method set to url.Values is identical, but adds lazy initialization.
+//
+// The filter helpers are not checked against the endpoint they are sent to,
+// prefer the typed queries like DayEntryQuery or InvoiceQuery.
 type Params url.Values
 
 // init initializes the Params type if it's nil
@@ -130,43 +134,38 @@ func (p *Params) IsClosed(closed bool) *Params {
 
 func (p *Params) UpdatedSince(t time.Time) *Params {
 	p.init()
-	p.Set("updated_since", t.UTC().String())
+	p.Set("updated_since", t.UTC().Format(UpdatedSinceFormat))
 	return p
 }
 
 func (p *Params) ForProject(project *Project) *Params {
 	p.init()
-	p.Set("project_id", string(project.Id()))
+	p.Set("project_id", strconv.Itoa(project.Id()))
 	return p
 }
 
 func (p *Params) ForUser(user *User) *Params {
 	p.init()
-	p.Set("user_id", string(user.Id()))
+	p.Set("user_id", strconv.Itoa(user.Id()))
 	return p
 }
 
 func (p *Params) ByClient(client *Client) *Params {
 	p.init()
-	p.Set("client", string(client.Id()))
+	p.Set("client", strconv.Itoa(client.Id()))
 	return p
 }
 
 func (p *Params) Page(page int) *Params {
 	p.init()
-	p.Set("page", string(page))
+	p.Set("page", strconv.Itoa(page))
 	return p
 }
 
-// Available status:
-//   open    - sent to the client but no payment recieved
-//   partial - partial payment was recorded
-//   draft   - Harvest did not sent this to a client, nor recorded any payments
-//   paid    - invoice paid in full
-//   unpaid  - unpaid invoices
-//   pastdue - past due invoices
-func (p *Params) Status(status string) *Params {
+// Status filters invoices by the given status. See the InvoiceStatus
+// constants for the available values.
+func (p *Params) Status(status InvoiceStatus) *Params {
 	p.init()
-	p.Set("status", status)
+	p.Set("status", string(status))
 	return p
 }
@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestParamsClone(t *testing.T) {
@@ -246,8 +247,8 @@ func TestParamsForTimeframe(t *testing.T) {
 	params := make(Params)
 
 	timeframe := Timeframe{
-		StartDate: Date(2010, 01, 01, nil),
-		EndDate:   Date(2012, 01, 01, nil),
+		StartDate: Date(2010, 01, 01),
+		EndDate:   Date(2012, 01, 01),
 	}
 
 	params.ForTimeframe(timeframe)
@@ -260,3 +261,43 @@ func TestParamsForTimeframe(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestParamsStatus(t *testing.T) {
+	var params Params
+
+	params.Status(PastDueStatus)
+
+	if status := params.Get("status"); status != "pastdue" {
+		t.Logf("Expected status to equal 'pastdue', got %q\n", status)
+		t.Fail()
+	}
+}
+
+func TestParamsIds(t *testing.T) {
+	var params Params
+
+	params.ForProject(&Project{ID: 1234}).ForUser(&User{ID: 56}).ByClient(&Client{ID: 789}).Page(10)
+
+	expected := Params{
+		"project_id": []string{"1234"},
+		"user_id":    []string{"56"},
+		"client":     []string{"789"},
+		"page":       []string{"10"},
+	}
+
+	if !reflect.DeepEqual(expected, params) {
+		t.Logf("Expected params to equal\n%v\n\tgot\n%v\n", expected, params)
+		t.Fail()
+	}
+}
+
+func TestParamsUpdatedSince(t *testing.T) {
+	var params Params
+
+	params.UpdatedSince(time.Date(2015, 7, 1, 10, 5, 0, 0, time.FixedZone("EST", -5*3600)))
+
+	if updatedSince := params.Get("updated_since"); updatedSince != "2015-07-01 15:05" {
+		t.Logf("Expected updated_since to equal '2015-07-01 15:05', got %q\n", updatedSince)
+		t.Fail()
+	}
+}
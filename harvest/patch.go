@@ -0,0 +1,128 @@
+package harvest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Patch wraps a CrudModel and restricts the fields marshaled to the given
+// field mask. Fields within the mask are always marshaled, even if they hold
+// their zero value, so that it is possible to set a field to false or 0.
+//
+// Fields are referenced either by their JSON name or by their struct field
+// name.
+type Patch struct {
+	model  CrudModel
+	fields []string
+}
+
+// NewPatch returns a Patch for the given model which will only marshal the
+// given fields.
+func NewPatch(model CrudModel, fields ...string) *Patch {
+	return &Patch{model: model, fields: fields}
+}
+
+// Model returns the wrapped model
+func (p *Patch) Model() CrudModel {
+	return p.model
+}
+
+// Fields returns the field mask of the patch
+func (p *Patch) Fields() []string {
+	return p.fields
+}
+
+func (p *Patch) Type() string {
+	return p.model.Type()
+}
+
+func (p *Patch) Id() int {
+	return p.model.Id()
+}
+
+func (p *Patch) SetId(id int) {
+	p.model.SetId(id)
+}
+
+// MarshalJSON marshals the fields within the field mask into a JSON object.
+//
+// It returns an error if the field mask references a field the model does
+// not have.
+func (p *Patch) MarshalJSON() ([]byte, error) {
+	modelValue := reflect.ValueOf(p.model)
+	if modelValue.Kind() != reflect.Ptr || modelValue.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("Can't patch %T, expected pointer to struct", p.model)
+	}
+	modelValue = modelValue.Elem()
+	patch := make(map[string]json.RawMessage)
+	for _, field := range p.fields {
+		index, jsonName := lookupField(modelValue.Type(), field)
+		if index == -1 {
+			return nil, fmt.Errorf("%T has no field %q", p.model, field)
+		}
+		marshaledField, err := json.Marshal(modelValue.Field(index).Addr().Interface())
+		if err != nil {
+			return nil, err
+		}
+		patch[jsonName] = marshaledField
+	}
+	return json.Marshal(patch)
+}
+
+// Validate validates the wrapped model if it implements Validator. Only
+// errors of fields within the field mask are reported.
+func (p *Patch) Validate() error {
+	err := validate(p.model)
+	validationError, ok := err.(*ValidationError)
+	if !ok {
+		return err
+	}
+	modelType := reflect.Indirect(reflect.ValueOf(p.model)).Type()
+	patchedFields := make(map[string]bool)
+	for _, field := range p.fields {
+		if index, jsonName := lookupField(modelType, field); index != -1 {
+			patchedFields[jsonName] = true
+		}
+	}
+	patchError := &ValidationError{Type: validationError.Type}
+	for _, fieldError := range validationError.Errors {
+		if patchedFields[fieldError.Field] {
+			patchError.Errors = append(patchError.Errors, fieldError)
+		}
+	}
+	return patchError.errorOrNil()
+}
+
+// lookupField returns the index and the JSON name of the struct field
+// referenced by field, either by its JSON name or its struct field name.
+//
+// It returns -1 as index if no such field exists.
+func lookupField(structType reflect.Type, field string) (int, string) {
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		jsonName := jsonFieldName(structField)
+		if jsonName != "" && (field == jsonName || field == structField.Name) {
+			return i, jsonName
+		}
+	}
+	return -1, ""
+}
+
+// jsonFieldName returns the name used for the field when marshaled to JSON.
+// It returns the empty string for fields ignored by encoding/json.
+func jsonFieldName(field reflect.StructField) string {
+	if field.PkgPath != "" {
+		return ""
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,127 @@
+package harvest
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestPatchMarshalJSON(t *testing.T) {
+	project := &Project{
+		ID:       12,
+		Name:     "Foo",
+		Active:   false,
+		Billable: false,
+		Budget:   0,
+		StartsOn: Date(2015, 2, 1),
+	}
+
+	var tests = []struct {
+		fields   []string
+		expected map[string]interface{}
+	}{
+		{
+			fields:   []string{"active", "billable"},
+			expected: map[string]interface{}{"active": false, "billable": false},
+		},
+		{
+			fields:   []string{"Budget", "name"},
+			expected: map[string]interface{}{"budget": 0.0, "name": "Foo"},
+		},
+		{
+			fields:   []string{"starts-on"},
+			expected: map[string]interface{}{"starts-on": "2015-02-01"},
+		},
+		{
+			fields:   []string{},
+			expected: map[string]interface{}{},
+		},
+	}
+	for _, test := range tests {
+		marshaled, err := json.Marshal(NewPatch(project, test.fields...))
+
+		if err != nil {
+			t.Logf("Expected no error, got %T: %v\n", err, err)
+			t.Fail()
+			continue
+		}
+
+		var actual map[string]interface{}
+		json.Unmarshal(marshaled, &actual)
+
+		if !reflect.DeepEqual(test.expected, actual) {
+			t.Logf("Expected patch to equal\n%+#v\n\tgot\n%+#v\n", test.expected, actual)
+			t.Fail()
+		}
+	}
+
+	// Unknown field
+	_, err := json.Marshal(NewPatch(project, "foo"))
+
+	if err == nil {
+		t.Logf("Expected error, got nil\n")
+		t.Fail()
+	}
+}
+
+func TestPatchCrudModel(t *testing.T) {
+	user := &User{ID: 12}
+	patch := NewPatch(user, "is_admin")
+
+	if patch.Type() != "User" {
+		t.Logf("Expected Type to equal 'User', got %q\n", patch.Type())
+		t.Fail()
+	}
+
+	if patch.Id() != 12 {
+		t.Logf("Expected Id to equal 12, got %d\n", patch.Id())
+		t.Fail()
+	}
+
+	patch.SetId(15)
+
+	if user.ID != 15 {
+		t.Logf("Expected SetId to set the id of the model, got %d\n", user.ID)
+		t.Fail()
+	}
+
+	if patch.Model() != user {
+		t.Logf("Expected Model to return the patched model\n")
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual([]string{"is_admin"}, patch.Fields()) {
+		t.Logf("Expected Fields to equal %v, got %v\n", []string{"is_admin"}, patch.Fields())
+		t.Fail()
+	}
+}
+
+func TestServiceUpdateWithFields(t *testing.T) {
+	var updated CrudModel
+	service := NewProjectService(nil, testApiUpdate(func(data CrudModel) error {
+		updated = data
+		return nil
+	}))
+	project := &Project{ID: 12}
+
+	service.Update(project, "active")
+
+	patch, ok := updated.(*Patch)
+	if !ok {
+		t.Logf("Expected Update to send a *Patch, got %T\n", updated)
+		t.FailNow()
+	}
+
+	if patch.Model() != project {
+		t.Logf("Expected patch to wrap the project\n")
+		t.Fail()
+	}
+
+	// No fields given
+	service.Update(project)
+
+	if updated != project {
+		t.Logf("Expected Update to send the project, got %T\n", updated)
+		t.Fail()
+	}
+}
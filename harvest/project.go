@@ -12,16 +12,17 @@ type Project struct {
 	Active   bool   `json:"active,omitempty"`
 	Notes    string `json:"notes,omitempty"`
 	Billable bool   `json:"billable,omitempty"`
-	/* Shows if the project is billed by task hourly rate or
-	person hourly rate. Options: Tasks, People, none */
-	BillBy                    string  `json:"bill_by,omitempty"`
-	CostBudget                float64 `json:"cost_budget,omitempty"`
-	CostBudgetIncludeExpenses bool    `json:"cost_budget_include_expenses,omitempty"`
-	HourlyRate                string  `json:"hourly_rate,omitempty"`
+	/* Shows if the project is billed by project hourly rate, task
+	hourly rate or person hourly rate. Options: Project, Tasks,
+	People, none */
+	BillBy                    BillBy `json:"bill_by,omitempty"`
+	CostBudget                *Money `json:"cost_budget,omitempty"`
+	CostBudgetIncludeExpenses bool   `json:"cost_budget_include_expenses,omitempty"`
+	HourlyRate                *Money `json:"hourly_rate,omitempty"`
 	/* Shows if the budget provided by total project hours,
 	total project cost, by tasks, by people or none provided.
 	Options: project, project_cost, task, person, none */
-	BudgetBy                         string    `json:"budget_by,omitempty"`
+	BudgetBy                         BudgetBy  `json:"budget_by,omitempty"`
 	Budget                           float64   `json:"budget,omitempty"`
 	NotifyWhenOverBudget             bool      `json:"notify_when_over_budget,omitempty"`
 	OverBudgetNotificationPercentage float32   `json:"over_budget_notification_percentage,omitempty"`
@@ -37,6 +38,8 @@ type Project struct {
 	are useful to constructing a full project timeline. */
 	HintEarliestRecordAt ShortDate `json:"hint_earliest_record_at,omitempty"`
 	HintLatestRecordAt   ShortDate `json:"hint_latest_record_at,omitempty"`
+	// JSON attributes not modeled by Project
+	Extra ExtraFields `json:"-"`
 }
 
 func (p *Project) Type() string {
@@ -51,12 +54,91 @@ func (p *Project) SetId(id int) {
 	p.ID = id
 }
 
+func (p *Project) ExtraFields() *ExtraFields {
+	return &p.Extra
+}
+
 func (p *Project) ToggleActive() bool {
 	p.Active = !p.Active
 	return p.Active
 }
 
+// Validate checks the budget settings of the project
+func (p *Project) Validate() error {
+	validationError := &ValidationError{Type: p.Type()}
+	if p.Budget < 0 {
+		validationError.add("budget", "must not be negative, got %v", p.Budget)
+	}
+	if p.OverBudgetNotificationPercentage < 0 || p.OverBudgetNotificationPercentage > 100 {
+		validationError.add("over_budget_notification_percentage", "must be between 0 and 100, got %v", p.OverBudgetNotificationPercentage)
+	}
+	return validationError.errorOrNil()
+}
+
 type ProjectPayload struct {
 	ErrorPayload
 	Project *Project `json:"project,omitempty"`
 }
+
+type BillBy string
+
+const (
+	BillByProject BillBy = "Project"
+	BillByTasks   BillBy = "Tasks"
+	BillByPeople  BillBy = "People"
+	BillByNone    BillBy = "none"
+)
+
+// IsValid returns true if b is one of the defined BillBy values or empty
+func (b BillBy) IsValid() bool {
+	switch b {
+	case "", BillByProject, BillByTasks, BillByPeople, BillByNone:
+		return true
+	}
+	return false
+}
+
+func (b BillBy) MarshalJSON() ([]byte, error) {
+	return marshalEnum(string(b))
+}
+
+func (b *BillBy) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data)
+	if err != nil {
+		return err
+	}
+	*b = BillBy(value)
+	return nil
+}
+
+type BudgetBy string
+
+const (
+	BudgetByProject     BudgetBy = "project"
+	BudgetByProjectCost BudgetBy = "project_cost"
+	BudgetByTask        BudgetBy = "task"
+	BudgetByPerson      BudgetBy = "person"
+	BudgetByNone        BudgetBy = "none"
+)
+
+// IsValid returns true if b is one of the defined BudgetBy values or empty
+func (b BudgetBy) IsValid() bool {
+	switch b {
+	case "", BudgetByProject, BudgetByProjectCost, BudgetByTask, BudgetByPerson, BudgetByNone:
+		return true
+	}
+	return false
+}
+
+func (b BudgetBy) MarshalJSON() ([]byte, error) {
+	return marshalEnum(string(b))
+}
+
+func (b *BudgetBy) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalEnum(data)
+	if err != nil {
+		return err
+	}
+	*b = BudgetBy(value)
+	return nil
+}
@@ -23,7 +23,9 @@ func (p *ProjectService) DayEntries(project *Project) *DayEntryService {
 	projectPath := p.endpoint.Path()
 	path := fmt.Sprintf("%s/%d/entries", projectPath, id)
 	endpoint := p.provider.CrudEndpoint(path)
-	return NewDayEntryService(endpoint)
+	service := NewDayEntryService(endpoint)
+	service.scope = projectScope
+	return service
 }
 
 func (p *ProjectService) Expenses(project *Project) *ExpenseService {
@@ -33,3 +35,12 @@ func (p *ProjectService) Expenses(project *Project) *ExpenseService {
 	endpoint := p.provider.CrudEndpoint(path)
 	return NewExpenseService(endpoint)
 }
+
+// Query populates projects with all projects matching query
+func (p *ProjectService) Query(projects *[]*Project, query ProjectQuery) error {
+	params, err := query.Values()
+	if err != nil {
+		return err
+	}
+	return p.All(projects, params)
+}
@@ -22,26 +22,41 @@ func NewProjectService(provider CrudEndpointProvider, endpoint CrudTogglerEndpoi
 	return &service
 }
 
+// Typed returns the typed service for projects
+func (s *ProjectService) Typed() *TogglerService[Project, *Project] {
+	return NewTogglerService[Project](s.endpoint)
+}
+
 func (s *ProjectService) All(projects *[]*Project, params url.Values) error {
-	return s.endpoint.All(projects, params)
+	all, err := s.Typed().All(params)
+	if all != nil {
+		*projects = all
+	}
+	return err
 }
 
 func (s *ProjectService) Find(id int, project *Project, params url.Values) error {
-	return s.endpoint.Find(id, project, params)
+	found, err := s.Typed().Find(id, params)
+	if found != nil {
+		*project = *found
+	}
+	return err
 }
 
 func (s *ProjectService) Create(project *Project) error {
-	return s.endpoint.Create(project)
+	return s.Typed().Create(project)
 }
 
-func (s *ProjectService) Update(project *Project) error {
-	return s.endpoint.Update(project)
+// Update updates the given project. If fields are provided, only these
+// fields are sent to the API, see NewPatch.
+func (s *ProjectService) Update(project *Project, fields ...string) error {
+	return s.Typed().Update(project, fields...)
 }
 
 func (s *ProjectService) Delete(project *Project) error {
-	return s.endpoint.Delete(project)
+	return s.Typed().Delete(project)
 }
 
 func (s *ProjectService) Toggle(project *Project) error {
-	return s.endpoint.Toggle(project)
+	return s.Typed().Toggle(project)
 }
@@ -0,0 +1,195 @@
+package harvest
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// UpdatedSinceFormat is the layout of the updated_since query param. The time
+// is always given in UTC.
+const UpdatedSinceFormat = "2006-01-02 15:04"
+
+// Query is a typed set of filters for a specific endpoint. Values validates
+// the filters and encodes them as query params.
+type Query interface {
+	Values() (url.Values, error)
+}
+
+// dayEntryScope determines the filters supported by an entries endpoint
+type dayEntryScope int
+
+const (
+	anyScope dayEntryScope = iota
+	projectScope
+	userScope
+)
+
+// DayEntryQuery filters the day entries of a project or a user. The
+// Timeframe is required. UserId is only supported for the entries of a
+// project, ProjectId only for the entries of a user.
+type DayEntryQuery struct {
+	Timeframe    Timeframe
+	UserId       int
+	ProjectId    int
+	Billable     *bool
+	OnlyBilled   bool
+	OnlyUnbilled bool
+	IsClosed     *bool
+	UpdatedSince time.Time
+}
+
+func (q DayEntryQuery) Values() (url.Values, error) {
+	return q.values(anyScope)
+}
+
+func (q DayEntryQuery) values(scope dayEntryScope) (url.Values, error) {
+	err := &ValidationError{Type: "DayEntryQuery"}
+	validateTimeframe(err, q.Timeframe, true)
+	if q.UserId != 0 && scope == userScope {
+		err.add("user_id", "is not supported for the entries of a user")
+	}
+	if q.ProjectId != 0 && scope == projectScope {
+		err.add("project_id", "is not supported for the entries of a project")
+	}
+	validateBilled(err, q.OnlyBilled, q.OnlyUnbilled)
+	if err := err.errorOrNil(); err != nil {
+		return nil, err
+	}
+	params := q.Timeframe.ToQuery()
+	setId(params, "user_id", q.UserId)
+	setId(params, "project_id", q.ProjectId)
+	setBool(params, "billable", q.Billable)
+	setBilled(params, q.OnlyBilled, q.OnlyUnbilled)
+	setBool(params, "is_closed", q.IsClosed)
+	setUpdatedSince(params, q.UpdatedSince)
+	return params, nil
+}
+
+// ExpenseQuery filters the expenses of a project or a user. The Timeframe is
+// required.
+type ExpenseQuery struct {
+	Timeframe    Timeframe
+	OnlyBilled   bool
+	OnlyUnbilled bool
+	IsClosed     *bool
+	UpdatedSince time.Time
+}
+
+func (q ExpenseQuery) Values() (url.Values, error) {
+	err := &ValidationError{Type: "ExpenseQuery"}
+	validateTimeframe(err, q.Timeframe, true)
+	validateBilled(err, q.OnlyBilled, q.OnlyUnbilled)
+	if err := err.errorOrNil(); err != nil {
+		return nil, err
+	}
+	params := q.Timeframe.ToQuery()
+	setBilled(params, q.OnlyBilled, q.OnlyUnbilled)
+	setBool(params, "is_closed", q.IsClosed)
+	setUpdatedSince(params, q.UpdatedSince)
+	return params, nil
+}
+
+// InvoiceQuery filters invoices. All filters are optional. Page starts at 1,
+// a Page of 0 requests the first page.
+type InvoiceQuery struct {
+	Timeframe    Timeframe
+	Status       InvoiceStatus
+	ClientId     int
+	UpdatedSince time.Time
+	Page         int
+}
+
+func (q InvoiceQuery) Values() (url.Values, error) {
+	err := &ValidationError{Type: "InvoiceQuery"}
+	validateTimeframe(err, q.Timeframe, false)
+	if q.Status != "" && !q.Status.IsValid() {
+		err.add("status", "is not a valid InvoiceStatus: %q", q.Status)
+	}
+	if q.Page < 0 {
+		err.add("page", "must not be negative")
+	}
+	if err := err.errorOrNil(); err != nil {
+		return nil, err
+	}
+	params := make(url.Values)
+	if !q.Timeframe.StartDate.IsZero() {
+		params = q.Timeframe.ToQuery()
+	}
+	if q.Status != "" {
+		params.Set("status", string(q.Status))
+	}
+	setId(params, "client", q.ClientId)
+	setUpdatedSince(params, q.UpdatedSince)
+	setId(params, "page", q.Page)
+	return params, nil
+}
+
+// ProjectQuery filters projects. All filters are optional.
+type ProjectQuery struct {
+	ClientId     int
+	UpdatedSince time.Time
+}
+
+func (q ProjectQuery) Values() (url.Values, error) {
+	params := make(url.Values)
+	setId(params, "client", q.ClientId)
+	setUpdatedSince(params, q.UpdatedSince)
+	return params, nil
+}
+
+// validateTimeframe adds errors for a timeframe which is only partially set or
+// ends before it starts. If required is true, the timeframe must be set.
+func validateTimeframe(err *ValidationError, timeframe Timeframe, required bool) {
+	from, to := timeframe.StartDate, timeframe.EndDate
+	if from.IsZero() && to.IsZero() && !required {
+		return
+	}
+	if from.IsZero() {
+		err.add("from", "is required")
+	}
+	if to.IsZero() {
+		err.add("to", "is required")
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		err.add("to", "must not be before from")
+	}
+}
+
+func validateBilled(err *ValidationError, onlyBilled, onlyUnbilled bool) {
+	if onlyBilled && onlyUnbilled {
+		err.add("only_billed", "can't be combined with only_unbilled")
+	}
+}
+
+func setId(params url.Values, key string, id int) {
+	if id != 0 {
+		params.Set(key, strconv.Itoa(id))
+	}
+}
+
+func setBool(params url.Values, key string, value *bool) {
+	if value == nil {
+		return
+	}
+	if *value {
+		params.Set(key, "yes")
+	} else {
+		params.Set(key, "no")
+	}
+}
+
+func setBilled(params url.Values, onlyBilled, onlyUnbilled bool) {
+	if onlyBilled {
+		params.Set("only_billed", "yes")
+	}
+	if onlyUnbilled {
+		params.Set("only_unbilled", "yes")
+	}
+}
+
+func setUpdatedSince(params url.Values, updatedSince time.Time) {
+	if !updatedSince.IsZero() {
+		params.Set("updated_since", updatedSince.UTC().Format(UpdatedSinceFormat))
+	}
+}
@@ -0,0 +1,156 @@
+package harvest
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDayEntryQueryValues(t *testing.T) {
+	billable := true
+	closed := false
+	query := DayEntryQuery{
+		Timeframe:    NewTimeframe(2015, 1, 1, 2015, 1, 31),
+		UserId:       12,
+		Billable:     &billable,
+		OnlyUnbilled: true,
+		IsClosed:     &closed,
+		UpdatedSince: time.Date(2015, 1, 2, 13, 30, 45, 0, time.FixedZone("CET", 3600)),
+	}
+
+	params, err := query.Values()
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	expected := url.Values{
+		"from":          []string{"20150101"},
+		"to":            []string{"20150131"},
+		"user_id":       []string{"12"},
+		"billable":      []string{"yes"},
+		"only_unbilled": []string{"yes"},
+		"is_closed":     []string{"no"},
+		"updated_since": []string{"2015-01-02 12:30"},
+	}
+
+	if !reflect.DeepEqual(expected, params) {
+		t.Logf("Expected params to equal\n%v\n\tgot\n%v\n", expected, params)
+		t.Fail()
+	}
+}
+
+func TestDayEntryQueryValidation(t *testing.T) {
+	timeframe := NewTimeframe(2015, 1, 1, 2015, 1, 31)
+	var tests = []struct {
+		query          DayEntryQuery
+		scope          dayEntryScope
+		expectedFields []string
+	}{
+		{DayEntryQuery{}, anyScope, []string{"from", "to"}},
+		{DayEntryQuery{Timeframe: NewTimeframe(2015, 2, 1, 2015, 1, 1)}, anyScope, []string{"to"}},
+		{DayEntryQuery{Timeframe: timeframe, UserId: 1}, userScope, []string{"user_id"}},
+		{DayEntryQuery{Timeframe: timeframe, ProjectId: 1}, projectScope, []string{"project_id"}},
+		{DayEntryQuery{Timeframe: timeframe, OnlyBilled: true, OnlyUnbilled: true}, anyScope, []string{"only_billed"}},
+		{DayEntryQuery{Timeframe: timeframe, UserId: 1}, projectScope, nil},
+		{DayEntryQuery{Timeframe: timeframe, ProjectId: 1}, userScope, nil},
+	}
+	for _, test := range tests {
+		_, err := test.query.values(test.scope)
+
+		var fields []string
+		if validationError, ok := err.(*ValidationError); ok {
+			for _, fieldError := range validationError.Errors {
+				fields = append(fields, fieldError.Field)
+			}
+		} else if err != nil {
+			t.Logf("Expected error to be a *ValidationError, got %T: %v\n", err, err)
+			t.Fail()
+		}
+
+		if !reflect.DeepEqual(test.expectedFields, fields) {
+			t.Logf("Expected invalid fields %v for %+v, got %v\n", test.expectedFields, test.query, fields)
+			t.Fail()
+		}
+	}
+}
+
+func TestInvoiceQueryValues(t *testing.T) {
+	var tests = []struct {
+		query       InvoiceQuery
+		expected    url.Values
+		expectError bool
+	}{
+		{InvoiceQuery{}, url.Values{}, false},
+		{
+			InvoiceQuery{Status: PaidStatus, ClientId: 3, Page: 2},
+			url.Values{"status": []string{"paid"}, "client": []string{"3"}, "page": []string{"2"}},
+			false,
+		},
+		{
+			InvoiceQuery{Timeframe: NewTimeframe(2015, 1, 1, 2015, 3, 31)},
+			url.Values{"from": []string{"20150101"}, "to": []string{"20150331"}},
+			false,
+		},
+		{InvoiceQuery{Status: "overdue"}, nil, true},
+		{InvoiceQuery{Page: -1}, nil, true},
+		{InvoiceQuery{Timeframe: Timeframe{StartDate: Date(2015, 1, 1)}}, nil, true},
+	}
+	for _, test := range tests {
+		params, err := test.query.Values()
+
+		if test.expectError && err == nil {
+			t.Logf("Expected error for %+v, got nil\n", test.query)
+			t.Fail()
+		}
+		if !test.expectError && err != nil {
+			t.Logf("Expected no error for %+v, got %T: %v\n", test.query, err, err)
+			t.Fail()
+		}
+
+		if !reflect.DeepEqual(test.expected, params) {
+			t.Logf("Expected params to equal %v, got %v\n", test.expected, params)
+			t.Fail()
+		}
+	}
+}
+
+func TestDayEntryServiceQuery(t *testing.T) {
+	var params url.Values
+	endpoint := testApiAll(func(data interface{}, p url.Values) error {
+		params = p
+		return nil
+	})
+	service := NewDayEntryService(endpoint)
+	service.scope = userScope
+
+	var dayEntries []*DayEntry
+
+	err := service.Query(&dayEntries, DayEntryQuery{Timeframe: NewTimeframe(2015, 1, 1, 2015, 1, 31), ProjectId: 1000})
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if projectId := params.Get("project_id"); projectId != "1000" {
+		t.Logf("Expected project_id to equal '1000', got %q\n", projectId)
+		t.Fail()
+	}
+
+	params = nil
+
+	err = service.Query(&dayEntries, DayEntryQuery{Timeframe: NewTimeframe(2015, 1, 1, 2015, 1, 31), UserId: 1})
+
+	if err == nil {
+		t.Logf("Expected error, got nil\n")
+		t.Fail()
+	}
+
+	if params != nil {
+		t.Logf("Expected endpoint not to be called with unsupported filters\n")
+		t.Fail()
+	}
+}
@@ -0,0 +1,59 @@
+package harvest
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type RecurringInvoice struct {
+	ID       int    `json:"id"`
+	ClientId int    `json:"client-id"`
+	Subject  string `json:"subject"`
+	Notes    string `json:"notes"`
+	Currency string `json:"currency"`
+	// the amount of every invoice issued by this profile
+	Amount Money `json:"amount"`
+	/* allowed values:
+	   weekly, biweekly, monthly, bimonthly, quarterly, semiannually, annually */
+	Frequency string `json:"frequency"`
+	// the date of the first issued invoice
+	StartsOn ShortDate `json:"starts-on"`
+	// the date of the last issued invoice, blank if issued infinitely
+	EndsOn ShortDate `json:"ends-on"`
+	// the date the next invoice will be issued
+	NextIssueAt ShortDate `json:"next-issue-at"`
+	// the number of days until an issued invoice is due
+	DueDays   int       `json:"due-days"`
+	Active    bool      `json:"active"`
+	UpdatedAt time.Time `json:"updated-at"`
+	CreatedAt time.Time `json:"created-at"`
+	// JSON attributes not modeled by RecurringInvoice
+	Extra ExtraFields `json:"-"`
+}
+
+func (r *RecurringInvoice) Id() int {
+	return r.ID
+}
+
+func (r *RecurringInvoice) SetId(id int) {
+	r.ID = id
+}
+
+func (r *RecurringInvoice) ExtraFields() *ExtraFields {
+	return &r.Extra
+}
+
+func (r *RecurringInvoice) Type() string {
+	return "recurring-invoice"
+}
+
+// UnmarshalJSON unmarshals the recurring invoice and sets its currency on all amounts
+func (r *RecurringInvoice) UnmarshalJSON(data []byte) error {
+	type recurringInvoice RecurringInvoice
+	err := json.Unmarshal(data, (*recurringInvoice)(r))
+	if err != nil {
+		return err
+	}
+	r.Amount = r.Amount.In(r.Currency)
+	return nil
+}
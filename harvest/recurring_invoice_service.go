@@ -0,0 +1,21 @@
+package harvest
+
+import "net/url"
+
+// RecurringInvoiceService provides read access to the recurring invoice
+// profiles of an account.
+type RecurringInvoiceService struct {
+	endpoint CrudEndpoint
+}
+
+func NewRecurringInvoiceService(endpoint CrudEndpoint) *RecurringInvoiceService {
+	return &RecurringInvoiceService{endpoint: endpoint}
+}
+
+func (s *RecurringInvoiceService) All(recurringInvoices *[]*RecurringInvoice, params url.Values) error {
+	return s.endpoint.All(recurringInvoices, params)
+}
+
+func (s *RecurringInvoiceService) Find(id int, recurringInvoice *RecurringInvoice, params url.Values) error {
+	return s.endpoint.Find(id, recurringInvoice, params)
+}
@@ -0,0 +1,384 @@
+package reporting
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mitch000001/go-harvest/harvest"
+)
+
+// HoursBudget is a budget in hours
+type HoursBudget struct {
+	Budget    harvest.Hours
+	Spent     harvest.Hours
+	Remaining harvest.Hours
+}
+
+func newHoursBudget(budget, spent harvest.Hours) HoursBudget {
+	return HoursBudget{Budget: budget, Spent: spent, Remaining: budget - spent}
+}
+
+// CostBudget is a budget in money. Spent includes Expenses if the project's
+// cost budget includes expenses.
+type CostBudget struct {
+	Budget    harvest.Money
+	Spent     harvest.Money
+	Expenses  harvest.Money
+	Remaining harvest.Money
+}
+
+// TaskBudget is the budget of a task within a project
+type TaskBudget struct {
+	Task *harvest.Task
+	HoursBudget
+}
+
+// PersonBudget is the budget of a user within a project
+type PersonBudget struct {
+	User *harvest.User
+	HoursBudget
+}
+
+// WeeklyBurn is the consumption within a week. Week is clipped to the
+// timeframe of the dataset.
+type WeeklyBurn struct {
+	Week  harvest.Timeframe
+	Hours harvest.Hours
+	Cost  harvest.Money
+}
+
+// ProjectBudget is the budget status of a project. Hours is used for budgets
+// by project, task and person, Cost for budgets by project cost. Tasks is only
+// set for budgets by task, People only for budgets by person.
+type ProjectBudget struct {
+	Project *harvest.Project
+	Hours   HoursBudget
+	Cost    CostBudget
+	// Weeks is the burn down within the timeframe of the dataset
+	Weeks []WeeklyBurn
+	// HoursPerWeek and CostPerWeek are the average consumption per week from
+	// the start of the project up to the day of the report
+	HoursPerWeek harvest.Hours
+	CostPerWeek  harvest.Money
+	// ExhaustedOn is the day the budget was or, at the current burn rate, will
+	// be used up. It is zero if there is no budget or nothing is spent.
+	ExhaustedOn harvest.ShortDate
+	Tasks       []TaskBudget
+	People      []PersonBudget
+}
+
+// IsCostBudget returns true if the budget is measured in money
+func (p *ProjectBudget) IsCostBudget() bool {
+	return p.Project.BudgetBy == harvest.BudgetByProjectCost
+}
+
+// Consumed returns the spent part of the budget in percent. It returns 0 if
+// there is no budget.
+func (p *ProjectBudget) Consumed() float64 {
+	budget, spent := p.amounts()
+	if budget <= 0 {
+		return 0
+	}
+	return spent / budget * 100
+}
+
+// amounts returns the budget and the spent amount in the unit of the budget
+func (p *ProjectBudget) amounts() (float64, float64) {
+	if p.IsCostBudget() {
+		return p.Cost.Budget.Float64(), p.Cost.Spent.Float64()
+	}
+	return p.Hours.Budget.Float64(), p.Hours.Spent.Float64()
+}
+
+// IsExhausted returns true if nothing of the budget remains
+func (p *ProjectBudget) IsExhausted() bool {
+	if p.IsCostBudget() {
+		return p.Cost.Remaining.Sign() <= 0
+	}
+	return p.Hours.Remaining <= 0
+}
+
+// BudgetTracker computes the budget status of projects. Hours are consumed by
+// all day entries, costs by the billable amount of day entries, see
+// RevenueReporter, and expenses if the project's CostBudgetIncludeExpenses is
+// set.
+//
+// The consumption covers the whole lifetime of a project: all day entries
+// and expenses of the dataset up to Today are counted, regardless of the
+// dataset's timeframe, which only defines the weeks of the burn down. Use
+// LoadBudgets to load the entries and expenses of the projects' lifetimes.
+type BudgetTracker struct {
+	// Today is the reference day for consumption, burn rates and forecasts.
+	// If zero, the end of the dataset's timeframe is used.
+	Today harvest.ShortDate
+	// WeekStart is the first day of the weeks of the report
+	WeekStart harvest.WeekStartDay
+	// Rounding is used to round costs to cents
+	Rounding harvest.RoundingMode
+}
+
+// LoadBudgets fetches the resources to track the budgets of all projects with
+// a budget. In contrast to Load, the day entries and expenses of these
+// projects are fetched for their whole lifetime, from the project's
+// HintEarliestRecordAt up to today, while timeframe only defines the burn
+// down of the dataset. If today is zero, the end of timeframe is used.
+func LoadBudgets(client *harvest.Harvest, timeframe harvest.Timeframe, today harvest.ShortDate) (*Dataset, error) {
+	if today.IsZero() {
+		today = timeframe.EndDate
+	}
+	data := &Dataset{Timeframe: timeframe}
+	if err := client.Clients.All(&data.Clients, nil); err != nil {
+		return nil, err
+	}
+	if err := client.Users.All(&data.Users, nil); err != nil {
+		return nil, err
+	}
+	if err := client.Projects.All(&data.Projects, nil); err != nil {
+		return nil, err
+	}
+	if err := client.Tasks.All(&data.Tasks, nil); err != nil {
+		return nil, err
+	}
+	for _, project := range data.Projects {
+		switch project.BudgetBy {
+		case harvest.BudgetByProject, harvest.BudgetByProjectCost, harvest.BudgetByTask, harvest.BudgetByPerson:
+		default:
+			continue
+		}
+		var taskAssignments []*harvest.TaskAssignment
+		if err := client.Projects.TaskAssignments(project).All(&taskAssignments, nil); err != nil {
+			return nil, err
+		}
+		data.TaskAssignments = append(data.TaskAssignments, taskAssignments...)
+		var userAssignments []*harvest.UserAssignment
+		if err := client.Projects.UserAssignments(project).All(&userAssignments, nil); err != nil {
+			return nil, err
+		}
+		data.UserAssignments = append(data.UserAssignments, userAssignments...)
+
+		lifetime := harvest.Timeframe{StartDate: lifetimeStart(project, timeframe), EndDate: today}
+		if today.Before(lifetime.StartDate) {
+			continue
+		}
+		options := harvest.ChunkOptions{Project: project}
+		err := client.Projects.DayEntries(project).Stream(harvest.DayEntryQuery{Timeframe: lifetime}, options, func(entry *harvest.DayEntry) error {
+			data.DayEntries = append(data.DayEntries, entry)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = client.Projects.Expenses(project).Stream(harvest.ExpenseQuery{Timeframe: lifetime}, options, func(expense *harvest.Expense) error {
+			data.Expenses = append(data.Expenses, expense)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// lifetimeStart returns the first day records of project are fetched from,
+// its HintEarliestRecordAt, StartsOn or creation date, whichever is known
+// first. If none is known, the start of timeframe is returned.
+func lifetimeStart(project *harvest.Project, timeframe harvest.Timeframe) harvest.ShortDate {
+	switch {
+	case !project.HintEarliestRecordAt.IsZero():
+		return project.HintEarliestRecordAt
+	case !project.StartsOn.IsZero():
+		return project.StartsOn
+	case !project.CreatedAt.IsZero():
+		return harvest.NewShortDate(project.CreatedAt)
+	}
+	return timeframe.StartDate
+}
+
+// Track returns the budget status of all projects of the dataset having a
+// budget, sorted by project name
+func (b *BudgetTracker) Track(data *Dataset) []ProjectBudget {
+	var budgets []ProjectBudget
+	for _, project := range data.Projects {
+		switch project.BudgetBy {
+		case harvest.BudgetByProject, harvest.BudgetByProjectCost, harvest.BudgetByTask, harvest.BudgetByPerson:
+			budgets = append(budgets, b.TrackProject(data, project))
+		}
+	}
+	sort.Slice(budgets, func(i, j int) bool {
+		a, b := budgets[i].Project, budgets[j].Project
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+	return budgets
+}
+
+// TrackProject returns the budget status of project
+func (b *BudgetTracker) TrackProject(data *Dataset, project *harvest.Project) ProjectBudget {
+	today := b.Today
+	if today.IsZero() {
+		today = data.Timeframe.EndDate
+	}
+	currency := data.client(project.ClientId).Currency
+	weeks := data.Timeframe.Split(harvest.PeriodWeek, b.WeekStart)
+	status := ProjectBudget{Project: project, Weeks: make([]WeeklyBurn, len(weeks))}
+	for i, week := range weeks {
+		status.Weeks[i] = WeeklyBurn{Week: week, Cost: harvest.Money{}.In(currency)}
+	}
+
+	// daily consumption in the unit of the budget, used for the forecast
+	daily := make(map[harvest.ShortDate]float64)
+	rates := NewRateResolver(data)
+	billability := NewBillability(data.Projects, data.TaskAssignments)
+	pricer := &RevenueReporter{Rounding: b.Rounding}
+	taskHours := make(map[int]harvest.Hours)
+	userHours := make(map[int]harvest.Hours)
+	var spentHours harvest.Hours
+	spentCost := harvest.Money{}.In(currency)
+	expenses := harvest.Money{}.In(currency)
+	for _, entry := range data.DayEntries {
+		if entry.ProjectId != project.ID || today.Before(entry.SpentAt) {
+			continue
+		}
+		cost := pricer.Price(entry, rates, billability).Amount.In(currency)
+		spentHours += entry.Hours
+		spentCost, _ = spentCost.Add(cost)
+		taskHours[entry.TaskId] += entry.Hours
+		userHours[entry.UserId] += entry.Hours
+		if week := timeframeIndex(weeks, entry.SpentAt); week != -1 {
+			status.Weeks[week].Hours += entry.Hours
+			status.Weeks[week].Cost, _ = status.Weeks[week].Cost.Add(cost)
+		}
+		if status.IsCostBudget() {
+			daily[entry.SpentAt] += cost.Float64()
+		} else {
+			daily[entry.SpentAt] += entry.Hours.Float64()
+		}
+	}
+	if status.IsCostBudget() && project.CostBudgetIncludeExpenses {
+		for _, expense := range data.Expenses {
+			date := expense.SpentAt
+			if expense.ProjectId != project.ID || today.Before(date) {
+				continue
+			}
+			cost := expense.TotalCost.In(currency)
+			expenses, _ = expenses.Add(cost)
+			spentCost, _ = spentCost.Add(cost)
+			if week := timeframeIndex(weeks, date); week != -1 {
+				status.Weeks[week].Cost, _ = status.Weeks[week].Cost.Add(cost)
+			}
+			daily[date] += cost.Float64()
+		}
+	}
+
+	status.Hours = newHoursBudget(harvest.HoursFromFloat(project.Budget), spentHours)
+	budgetCost := project.CostBudget.Value().In(currency)
+	remainingCost, _ := budgetCost.Sub(spentCost)
+	status.Cost = CostBudget{Budget: budgetCost, Spent: spentCost, Expenses: expenses, Remaining: remainingCost}
+
+	switch project.BudgetBy {
+	case harvest.BudgetByTask:
+		var total harvest.Hours
+		for _, assignment := range data.TaskAssignments {
+			if assignment.ProjectId != project.ID {
+				continue
+			}
+			budget := harvest.HoursFromFloat(assignment.Budget)
+			total += budget
+			status.Tasks = append(status.Tasks, TaskBudget{data.task(assignment.TaskId), newHoursBudget(budget, taskHours[assignment.TaskId])})
+		}
+		sort.Slice(status.Tasks, func(i, j int) bool {
+			return status.Tasks[i].Task.Name < status.Tasks[j].Task.Name
+		})
+		if project.Budget == 0 {
+			status.Hours = newHoursBudget(total, spentHours)
+		}
+	case harvest.BudgetByPerson:
+		var total harvest.Hours
+		var users []*harvest.User
+		budgets := make(map[int]harvest.Hours)
+		for _, assignment := range data.UserAssignments {
+			if assignment.ProjectId != project.ID {
+				continue
+			}
+			budget := harvest.HoursFromFloat(assignment.Budget)
+			total += budget
+			budgets[assignment.UserId] = budget
+			users = append(users, data.user(assignment.UserId))
+		}
+		sortUsers(users)
+		for _, user := range users {
+			status.People = append(status.People, PersonBudget{user, newHoursBudget(budgets[user.ID], userHours[user.ID])})
+		}
+		if project.Budget == 0 {
+			status.Hours = newHoursBudget(total, spentHours)
+		}
+	}
+
+	elapsed := elapsedWeeks(projectStart(project, daily, data.Timeframe), today)
+	budget, spent := status.amounts()
+	if elapsed > 0 {
+		status.HoursPerWeek = harvest.HoursFromFloat(spentHours.Float64() / elapsed)
+		status.CostPerWeek = spentCost.Mul(1/elapsed, b.Rounding).Round(2, b.Rounding)
+	}
+	status.ExhaustedOn = exhaustionDate(daily, budget, spent, spent/elapsed, today)
+	return status
+}
+
+// projectStart returns the first day of the project, the earliest of its
+// HintEarliestRecordAt, StartsOn and the first day with consumption. If none
+// is known, the start of timeframe is returned.
+func projectStart(project *harvest.Project, daily map[harvest.ShortDate]float64, timeframe harvest.Timeframe) harvest.ShortDate {
+	var start harvest.ShortDate
+	earliest := func(date harvest.ShortDate) {
+		if !date.IsZero() && (start.IsZero() || date.Before(start)) {
+			start = date
+		}
+	}
+	earliest(project.HintEarliestRecordAt)
+	earliest(project.StartsOn)
+	for day := range daily {
+		earliest(day)
+	}
+	if start.IsZero() {
+		return timeframe.StartDate
+	}
+	return start
+}
+
+// elapsedWeeks returns the number of weeks from start to today, including
+// both days
+func elapsedWeeks(start, today harvest.ShortDate) float64 {
+	if today.Before(start) {
+		return 0
+	}
+	return float64(len(harvest.Timeframe{StartDate: start, EndDate: today}.Days())) / 7
+}
+
+// exhaustionDate returns the day on which the budget was used up, or will be
+// at the given burn rate per week. It returns the zero date if there is no
+// budget or no consumption.
+func exhaustionDate(daily map[harvest.ShortDate]float64, budget, spent, perWeek float64, today harvest.ShortDate) harvest.ShortDate {
+	if budget <= 0 {
+		return harvest.ShortDate{}
+	}
+	if spent >= budget {
+		days := make([]harvest.ShortDate, 0, len(daily))
+		for day := range daily {
+			days = append(days, day)
+		}
+		sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+		var sum float64
+		for _, day := range days {
+			sum += daily[day]
+			if sum >= budget {
+				return day
+			}
+		}
+	}
+	if perWeek <= 0 || math.IsInf(perWeek, 0) || math.IsNaN(perWeek) {
+		return harvest.ShortDate{}
+	}
+	return today.AddDays(int(math.Ceil((budget - spent) / perWeek * 7)))
+}
@@ -0,0 +1,160 @@
+package reporting
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mitch000001/go-harvest/harvest"
+)
+
+func testBudgetDataset() *Dataset {
+	return &Dataset{
+		Timeframe: harvest.NewTimeframe(2015, 1, 5, 2015, 1, 18),
+		Clients:   []*harvest.Client{{ID: 1, Currency: "EUR"}},
+		Users: []*harvest.User{
+			{ID: 1, LastName: "Adams"},
+			{ID: 2, LastName: "Brown"},
+		},
+		Projects: []*harvest.Project{
+			{ID: 1, Name: "Hours", BudgetBy: harvest.BudgetByProject, Budget: 40, HintEarliestRecordAt: harvest.Date(2014, 12, 29)},
+			{ID: 2, Name: "Cost", ClientId: 1, BudgetBy: harvest.BudgetByProjectCost, CostBudget: harvest.MoneyFromFloat(1000, "").Ptr(), CostBudgetIncludeExpenses: true, Billable: true, BillBy: harvest.BillByProject, HourlyRate: harvest.MoneyFromFloat(100, "").Ptr()},
+			{ID: 3, Name: "Task", BudgetBy: harvest.BudgetByTask},
+			{ID: 4, Name: "Person", BudgetBy: harvest.BudgetByPerson},
+			{ID: 5, Name: "None", BudgetBy: harvest.BudgetByNone},
+		},
+		Tasks: []*harvest.Task{
+			{ID: 1, Name: "Design"},
+			{ID: 2, Name: "Build"},
+		},
+		TaskAssignments: []*harvest.TaskAssignment{
+			{ProjectId: 2, TaskId: 1, Billable: true},
+			{ProjectId: 3, TaskId: 1, Budget: 5},
+			{ProjectId: 3, TaskId: 2, Budget: 3},
+		},
+		UserAssignments: []*harvest.UserAssignment{
+			{ProjectId: 4, UserId: 1, Budget: 10},
+		},
+		DayEntries: []*harvest.DayEntry{
+			{ID: 8, ProjectId: 1, UserId: 1, Hours: 10 * harvest.Hour, SpentAt: harvest.Date(2014, 12, 29)},
+			{ID: 1, ProjectId: 1, UserId: 1, Hours: 10 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 6)},
+			{ID: 2, ProjectId: 1, UserId: 1, Hours: 10 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 13)},
+			{ID: 3, ProjectId: 2, TaskId: 1, UserId: 1, Hours: 4 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 5)},
+			{ID: 4, ProjectId: 2, TaskId: 1, UserId: 1, Hours: 5 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 14)},
+			{ID: 5, ProjectId: 3, TaskId: 1, UserId: 1, Hours: 6 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 7)},
+			{ID: 6, ProjectId: 4, TaskId: 1, UserId: 1, Hours: 4 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 7)},
+			{ID: 7, ProjectId: 4, TaskId: 1, UserId: 2, Hours: 2 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 8)},
+		},
+		Expenses: []*harvest.Expense{
+			{ID: 1, ProjectId: 2, TotalCost: harvest.MoneyFromFloat(200, ""), SpentAt: harvest.Date(2015, 1, 12)},
+		},
+	}
+}
+
+func TestBudgetTrackerTrack(t *testing.T) {
+	tracker := &BudgetTracker{Today: harvest.Date(2015, 1, 18), WeekStart: harvest.Monday}
+
+	budgets := tracker.Track(testBudgetDataset())
+
+	var names []string
+	for _, budget := range budgets {
+		names = append(names, budget.Project.Name)
+	}
+
+	if !reflect.DeepEqual([]string{"Cost", "Hours", "Person", "Task"}, names) {
+		t.Logf("Expected budgets of projects with a budget sorted by name, got %v\n", names)
+		t.FailNow()
+	}
+
+	// Budget by hours
+	hours := budgets[1]
+
+	expectedHours := HoursBudget{Budget: 40 * harvest.Hour, Spent: 30 * harvest.Hour, Remaining: 10 * harvest.Hour}
+
+	if hours.Hours != expectedHours {
+		t.Logf("Expected hours budget %+v, got %+v\n", expectedHours, hours.Hours)
+		t.Fail()
+	}
+
+	if hours.HoursPerWeek != 10*harvest.Hour {
+		t.Logf("Expected 10 hours per week, got %v\n", hours.HoursPerWeek)
+		t.Fail()
+	}
+
+	if hours.ExhaustedOn != harvest.Date(2015, 1, 25) {
+		t.Logf("Expected budget to be exhausted on 2015-01-25, got %s\n", hours.ExhaustedOn)
+		t.Fail()
+	}
+
+	if len(hours.Weeks) != 2 || hours.Weeks[0].Hours != 10*harvest.Hour || hours.Weeks[1].Hours != 10*harvest.Hour {
+		t.Logf("Expected 10 hours in both weeks of the timeframe, got %+v\n", hours.Weeks)
+		t.Fail()
+	}
+
+	// Budget by cost including expenses
+	cost := budgets[0]
+
+	expectedCost := CostBudget{
+		Budget:    harvest.MoneyFromFloat(1000, "EUR"),
+		Spent:     harvest.MoneyFromFloat(1100, "EUR"),
+		Expenses:  harvest.MoneyFromFloat(200, "EUR"),
+		Remaining: harvest.MoneyFromFloat(-100, "EUR"),
+	}
+
+	if cost.Cost != expectedCost {
+		t.Logf("Expected cost budget %+v, got %+v\n", expectedCost, cost.Cost)
+		t.Fail()
+	}
+
+	if !cost.IsExhausted() || cost.ExhaustedOn != harvest.Date(2015, 1, 14) {
+		t.Logf("Expected cost budget to be exhausted on 2015-01-14, got %s\n", cost.ExhaustedOn)
+		t.Fail()
+	}
+
+	if cost.CostPerWeek != harvest.MoneyFromFloat(550, "EUR") {
+		t.Logf("Expected 550 EUR per week, got %v\n", cost.CostPerWeek)
+		t.Fail()
+	}
+
+	// Budget by task
+	task := budgets[3]
+
+	expectedTasks := []TaskBudget{
+		{&harvest.Task{ID: 2, Name: "Build"}, HoursBudget{3 * harvest.Hour, 0, 3 * harvest.Hour}},
+		{&harvest.Task{ID: 1, Name: "Design"}, HoursBudget{5 * harvest.Hour, 6 * harvest.Hour, -1 * harvest.Hour}},
+	}
+
+	if !reflect.DeepEqual(expectedTasks, task.Tasks) {
+		t.Logf("Expected task budgets\n%+v\n\tgot\n%+v\n", expectedTasks, task.Tasks)
+		t.Fail()
+	}
+
+	if task.Hours.Budget != 8*harvest.Hour {
+		t.Logf("Expected project budget to be the sum of task budgets, got %v\n", task.Hours.Budget)
+		t.Fail()
+	}
+
+	// Budget by person
+	person := budgets[2]
+
+	if len(person.People) != 1 || person.People[0].User.ID != 1 || person.People[0].Remaining != 6*harvest.Hour {
+		t.Logf("Expected 6 remaining hours for user 1, got %+v\n", person.People)
+		t.Fail()
+	}
+
+	if person.Hours.Spent != 6*harvest.Hour {
+		t.Logf("Expected 6 spent hours on the project, got %v\n", person.Hours.Spent)
+		t.Fail()
+	}
+}
+
+func TestBudgetTrackerNoConsumption(t *testing.T) {
+	data := testBudgetDataset()
+	data.DayEntries = nil
+
+	budget := (&BudgetTracker{}).TrackProject(data, data.Projects[0])
+
+	if !budget.ExhaustedOn.IsZero() {
+		t.Logf("Expected no exhaustion date without consumption, got %s\n", budget.ExhaustedOn)
+		t.Fail()
+	}
+}
@@ -0,0 +1,185 @@
+package reporting
+
+import (
+	"github.com/mitch000001/go-harvest/harvest"
+	"github.com/mitch000001/go-harvest/harvest/calendar"
+)
+
+// Contract are the contractual hours per week of a user. A contract is
+// effective from From to Until, including both days. A zero From or Until
+// leaves the range open.
+type Contract struct {
+	UserId      int
+	WeeklyHours harvest.Hours
+	From        harvest.ShortDate
+	Until       harvest.ShortDate
+}
+
+// IsEffective returns true if the contract is effective at date
+func (c Contract) IsEffective(date harvest.ShortDate) bool {
+	if !c.From.IsZero() && date.Before(c.From) {
+		return false
+	}
+	if !c.Until.IsZero() && c.Until.Before(date) {
+		return false
+	}
+	return true
+}
+
+// WeeklyBalance is the balance of a user within a week. Week is clipped to
+// the timeframe of the dataset. Balance is positive for overtime and negative
+// for under-time, Running is the balance carried forward including this week.
+type WeeklyBalance struct {
+	Week       harvest.Timeframe
+	Contracted harvest.Hours
+	Worked     harvest.Hours
+	Balance    harvest.Hours
+	Running    harvest.Hours
+}
+
+// Overtime returns the hours worked beyond the contract, or zero
+func (w WeeklyBalance) Overtime() harvest.Hours {
+	if w.Balance > 0 {
+		return w.Balance
+	}
+	return 0
+}
+
+// Undertime returns the contracted hours not worked, or zero
+func (w WeeklyBalance) Undertime() harvest.Hours {
+	if w.Balance < 0 {
+		return -w.Balance
+	}
+	return 0
+}
+
+// UserBalance is the balance of a user within the timeframe of the report.
+// Closing is the Opening balance plus Balance.
+type UserBalance struct {
+	User       *harvest.User
+	Opening    harvest.Hours
+	Contracted harvest.Hours
+	Worked     harvest.Hours
+	Balance    harvest.Hours
+	Closing    harvest.Hours
+	Weeks      []WeeklyBalance
+}
+
+// OvertimeReport lists the balances of all users with a contract, sorted by
+// name
+type OvertimeReport struct {
+	Timeframe harvest.Timeframe
+	Users     []UserBalance
+}
+
+// OvertimeCalculator compares the hours worked per user and week with the
+// contractual hours. The contracted hours of a week are prorated over the
+// working days of the user's weekly pattern, so that contracts may change
+// within a week. Weeks clipped by the timeframe are prorated as well, and no
+// hours are contracted on holidays. Only hours worked on days with an
+// effective contract are counted.
+type OvertimeCalculator struct {
+	Contracts []Contract
+	// Calendar provides the working days of the users. A day's share of the
+	// weekly hours is its expected hours relative to the user's weekly
+	// pattern. If nil, the weekly hours are spread from Monday to Friday.
+	Calendar *calendar.Calendar
+	// WeekStart is the first day of the weeks of the report
+	WeekStart harvest.WeekStartDay
+	// Opening are the balances carried forward from before the timeframe, by
+	// user id
+	Opening map[int]harvest.Hours
+}
+
+// NewOvertimeCalculator returns an OvertimeCalculator for contracts using
+// the week start day of company
+func NewOvertimeCalculator(company *harvest.Company, contracts ...Contract) *OvertimeCalculator {
+	return &OvertimeCalculator{Contracts: contracts, WeekStart: company.WeekStartDay}
+}
+
+// contract returns the contract of user effective at date. If several
+// contracts are effective, the one starting last wins.
+func (o *OvertimeCalculator) contract(userId int, date harvest.ShortDate) (Contract, bool) {
+	var effective Contract
+	found := false
+	for _, contract := range o.Contracts {
+		if contract.UserId != userId || !contract.IsEffective(date) {
+			continue
+		}
+		if !found || effective.From.Before(contract.From) {
+			effective = contract
+			found = true
+		}
+	}
+	return effective, found
+}
+
+// Calculate returns the weekly balances of all users of the dataset having a
+// contract effective within its timeframe
+func (o *OvertimeCalculator) Calculate(data *Dataset) *OvertimeReport {
+	report := &OvertimeReport{Timeframe: data.Timeframe}
+	weeks := data.Timeframe.Split(harvest.PeriodWeek, o.WeekStart)
+
+	worked := make(map[int]map[harvest.ShortDate]harvest.Hours)
+	for _, entry := range data.DayEntries {
+		if !data.Timeframe.Contains(entry.SpentAt) {
+			continue
+		}
+		if worked[entry.UserId] == nil {
+			worked[entry.UserId] = make(map[harvest.ShortDate]harvest.Hours)
+		}
+		worked[entry.UserId][entry.SpentAt] += entry.Hours
+	}
+
+	seen := make(map[int]bool)
+	var users []*harvest.User
+	for _, contract := range o.Contracts {
+		if seen[contract.UserId] {
+			continue
+		}
+		for _, day := range data.Timeframe.Days() {
+			if contract.IsEffective(day) {
+				seen[contract.UserId] = true
+				users = append(users, data.user(contract.UserId))
+				break
+			}
+		}
+	}
+	sortUsers(users)
+
+	cal := o.Calendar
+	if cal == nil {
+		cal = calendar.New(calendar.StandardWeek(8 * harvest.Hour))
+	}
+	for _, user := range users {
+		weekly := cal.Pattern(user.ID).Total().Float64()
+		opening := o.Opening[user.ID]
+		balance := UserBalance{User: user, Opening: opening, Weeks: make([]WeeklyBalance, len(weeks))}
+		running := opening
+		for i, week := range weeks {
+			var contracted float64
+			var workedHours harvest.Hours
+			for _, day := range week.Days() {
+				contract, ok := o.contract(user.ID, day)
+				if !ok {
+					continue
+				}
+				if weekly > 0 {
+					contracted += contract.WeeklyHours.Float64() * cal.ExpectedHours(user.ID, day).Float64() / weekly
+				}
+				workedHours += worked[user.ID][day]
+			}
+			weekBalance := WeeklyBalance{Week: week, Contracted: harvest.HoursFromFloat(contracted), Worked: workedHours}
+			weekBalance.Balance = weekBalance.Worked - weekBalance.Contracted
+			running += weekBalance.Balance
+			weekBalance.Running = running
+			balance.Weeks[i] = weekBalance
+			balance.Contracted += weekBalance.Contracted
+			balance.Worked += weekBalance.Worked
+			balance.Balance += weekBalance.Balance
+		}
+		balance.Closing = running
+		report.Users = append(report.Users, balance)
+	}
+	return report
+}
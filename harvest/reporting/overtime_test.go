@@ -0,0 +1,169 @@
+package reporting
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mitch000001/go-harvest/harvest"
+	"github.com/mitch000001/go-harvest/harvest/calendar"
+)
+
+func TestContractIsEffective(t *testing.T) {
+	var tests = []struct {
+		contract  Contract
+		date      harvest.ShortDate
+		effective bool
+	}{
+		{Contract{}, harvest.Date(2015, 1, 5), true},
+		{Contract{From: harvest.Date(2015, 1, 5)}, harvest.Date(2015, 1, 5), true},
+		{Contract{From: harvest.Date(2015, 1, 5)}, harvest.Date(2015, 1, 4), false},
+		{Contract{Until: harvest.Date(2015, 1, 5)}, harvest.Date(2015, 1, 5), true},
+		{Contract{Until: harvest.Date(2015, 1, 5)}, harvest.Date(2015, 1, 6), false},
+	}
+	for _, test := range tests {
+		effective := test.contract.IsEffective(test.date)
+
+		if effective != test.effective {
+			t.Logf("Expected contract %+v to be effective at %s: %t, got %t\n", test.contract, test.date, test.effective, effective)
+			t.Fail()
+		}
+	}
+}
+
+func TestOvertimeCalculatorCalculate(t *testing.T) {
+	calculator := NewOvertimeCalculator(
+		&harvest.Company{WeekStartDay: harvest.Monday},
+		Contract{UserId: 2, WeeklyHours: 10 * harvest.Hour},
+		Contract{UserId: 1, WeeklyHours: 7 * harvest.Hour, Until: harvest.Date(2015, 1, 7)},
+		Contract{UserId: 1, WeeklyHours: 14 * harvest.Hour, From: harvest.Date(2015, 1, 8)},
+		Contract{UserId: 3, WeeklyHours: 40 * harvest.Hour, Until: harvest.Date(2014, 12, 31)},
+	)
+	calculator.Opening = map[int]harvest.Hours{2: 2 * harvest.Hour}
+
+	report := calculator.Calculate(testDataset())
+
+	if len(report.Users) != 2 {
+		t.Logf("Expected balances of 2 users, got %d\n", len(report.Users))
+		t.FailNow()
+	}
+
+	firstWeek := harvest.NewTimeframe(2015, 1, 5, 2015, 1, 11)
+	secondWeek := harvest.NewTimeframe(2015, 1, 12, 2015, 1, 18)
+
+	var tests = []struct {
+		balance UserBalance
+		userId  int
+		closing harvest.Hours
+		weeks   []WeeklyBalance
+	}{
+		{
+			report.Users[0], 2, 0,
+			[]WeeklyBalance{
+				{Week: firstWeek, Contracted: 10 * harvest.Hour, Worked: 12 * harvest.Hour, Balance: 2 * harvest.Hour, Running: 4 * harvest.Hour},
+				{Week: secondWeek, Contracted: 10 * harvest.Hour, Worked: 6 * harvest.Hour, Balance: -4 * harvest.Hour, Running: 0},
+			},
+		},
+		{
+			// 3 days of 7 hours and 2 days of 14 hours per week
+			report.Users[1], 1, -(18*harvest.Hour + 48*harvest.Minute),
+			[]WeeklyBalance{
+				{Week: firstWeek, Contracted: 9*harvest.Hour + 48*harvest.Minute, Worked: 0, Balance: -(9*harvest.Hour + 48*harvest.Minute), Running: -(9*harvest.Hour + 48*harvest.Minute)},
+				{Week: secondWeek, Contracted: 14 * harvest.Hour, Worked: 5 * harvest.Hour, Balance: -9 * harvest.Hour, Running: -(18*harvest.Hour + 48*harvest.Minute)},
+			},
+		},
+	}
+	for _, test := range tests {
+		if test.balance.User.ID != test.userId {
+			t.Logf("Expected user %d, got %d\n", test.userId, test.balance.User.ID)
+			t.Fail()
+		}
+		if test.balance.Closing != test.closing {
+			t.Logf("Expected closing balance of user %d to equal %s, got %s\n", test.userId, test.closing, test.balance.Closing)
+			t.Fail()
+		}
+		if !reflect.DeepEqual(test.weeks, test.balance.Weeks) {
+			t.Logf("Expected weeks of user %d to equal %+v, got %+v\n", test.userId, test.weeks, test.balance.Weeks)
+			t.Fail()
+		}
+	}
+
+	if overtime := report.Users[0].Weeks[0].Overtime(); overtime != 2*harvest.Hour {
+		t.Logf("Expected overtime of 2 hours, got %s\n", overtime)
+		t.Fail()
+	}
+	if undertime := report.Users[0].Weeks[1].Undertime(); undertime != 4*harvest.Hour {
+		t.Logf("Expected undertime of 4 hours, got %s\n", undertime)
+		t.Fail()
+	}
+}
+
+func TestOvertimeCalculatorCalculateClippedWeek(t *testing.T) {
+	var tests = []struct {
+		holidays   []calendar.Holiday
+		contracted harvest.Hours
+		balance    harvest.Hours
+	}{
+		{nil, 16 * harvest.Hour, 0},
+		{[]calendar.Holiday{{Date: harvest.Date(2015, 1, 9)}}, 8 * harvest.Hour, 8 * harvest.Hour},
+	}
+	for _, test := range tests {
+		cal := calendar.New(calendar.StandardWeek(8 * harvest.Hour))
+		cal.AddHolidays(test.holidays...)
+		calculator := &OvertimeCalculator{
+			Contracts: []Contract{{UserId: 1, WeeklyHours: 40 * harvest.Hour}},
+			WeekStart: harvest.Monday,
+			Calendar:  cal,
+		}
+		// thursday to sunday
+		data := &Dataset{
+			Timeframe: harvest.NewTimeframe(2015, 1, 8, 2015, 1, 11),
+			DayEntries: []*harvest.DayEntry{
+				{ID: 1, UserId: 1, Hours: 8 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 8)},
+				{ID: 2, UserId: 1, Hours: 8 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 9)},
+			},
+		}
+
+		report := calculator.Calculate(data)
+
+		if len(report.Users) != 1 || len(report.Users[0].Weeks) != 1 {
+			t.Logf("Expected a single week of a single user, got %+v\n", report.Users)
+			t.Fail()
+			continue
+		}
+		week := report.Users[0].Weeks[0]
+		if week.Contracted != test.contracted || week.Balance != test.balance {
+			t.Logf("Expected %s contracted hours and a balance of %s with holidays %v, got %s and %s\n", test.contracted, test.balance, test.holidays, week.Contracted, week.Balance)
+			t.Fail()
+		}
+	}
+}
+
+func TestOvertimeCalculatorCalculateContractStart(t *testing.T) {
+	calculator := &OvertimeCalculator{
+		Contracts: []Contract{{UserId: 1, WeeklyHours: 40 * harvest.Hour, From: harvest.Date(2015, 1, 7)}},
+		WeekStart: harvest.Monday,
+	}
+	data := &Dataset{
+		Timeframe: harvest.NewTimeframe(2015, 1, 5, 2015, 1, 11),
+		DayEntries: []*harvest.DayEntry{
+			// monday, before the contract started
+			{ID: 1, UserId: 1, Hours: 8 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 5)},
+			{ID: 2, UserId: 1, Hours: 8 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 7)},
+			{ID: 3, UserId: 1, Hours: 8 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 8)},
+			{ID: 4, UserId: 1, Hours: 8 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 9)},
+		},
+	}
+
+	report := calculator.Calculate(data)
+
+	if len(report.Users) != 1 {
+		t.Logf("Expected balances of 1 user, got %d\n", len(report.Users))
+		t.FailNow()
+	}
+
+	expected := WeeklyBalance{Week: data.Timeframe, Contracted: 24 * harvest.Hour, Worked: 24 * harvest.Hour}
+	if !reflect.DeepEqual([]WeeklyBalance{expected}, report.Users[0].Weeks) {
+		t.Logf("Expected weeks to equal %+v, got %+v\n", []WeeklyBalance{expected}, report.Users[0].Weeks)
+		t.Fail()
+	}
+}
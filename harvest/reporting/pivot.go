@@ -0,0 +1,273 @@
+package reporting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mitch000001/go-harvest/harvest"
+)
+
+// Dimension is a property day entries and expenses are grouped by
+type Dimension string
+
+const (
+	ByClient     Dimension = "client"
+	ByProject    Dimension = "project"
+	ByTask       Dimension = "task"
+	ByUser       Dimension = "user"
+	ByDepartment Dimension = "department"
+	ByDay        Dimension = "day"
+	ByWeek       Dimension = "week"
+	ByMonth      Dimension = "month"
+	ByBilled     Dimension = "billed"
+	ByClosed     Dimension = "closed"
+)
+
+// IsValid returns true if d is a known dimension
+func (d Dimension) IsValid() bool {
+	switch d {
+	case ByClient, ByProject, ByTask, ByUser, ByDepartment, ByDay, ByWeek, ByMonth, ByBilled, ByClosed:
+		return true
+	}
+	return false
+}
+
+// Value is the value of a dimension within a group. ID is set for clients,
+// projects, tasks and users, and is 1 for billed or closed and 0 otherwise.
+// Date is set for days, weeks and months, and is the first day of the week or
+// month. Name is the resolved name, e.g. the full name of a user.
+//
+// Clients, projects, tasks and users without a name in the dataset, e.g.
+// deleted ones, are Unresolved and named by their ID, e.g. "#42".
+type Value struct {
+	Dimension  Dimension
+	ID         int
+	Date       harvest.ShortDate
+	Name       string
+	Unresolved bool
+}
+
+func (v *Value) resolve(id int, name string) {
+	v.ID, v.Name = id, name
+	if name == "" {
+		v.Name = fmt.Sprintf("#%d", id)
+		v.Unresolved = true
+	}
+}
+
+func (v Value) less(other Value) bool {
+	if !v.Date.Equal(other.Date) {
+		return v.Date.Before(other.Date)
+	}
+	if v.Name != other.Name {
+		return v.Name < other.Name
+	}
+	return v.ID < other.ID
+}
+
+// Group aggregates all day entries or expenses sharing the same values and
+// currency. Hours is only summed for day entries, Amount only for expenses.
+type Group struct {
+	Values []Value
+	Count  int
+	Hours  harvest.Hours
+	Amount harvest.Money
+}
+
+// Value returns the value of dimension d within the group
+func (g *Group) Value(d Dimension) (Value, bool) {
+	for _, value := range g.Values {
+		if value.Dimension == d {
+			return value, true
+		}
+	}
+	return Value{}, false
+}
+
+// AverageHours returns the hours per day entry
+func (g *Group) AverageHours() harvest.Hours {
+	if g.Count == 0 {
+		return 0
+	}
+	return g.Hours / harvest.Hours(g.Count)
+}
+
+// AverageAmount returns the amount per expense rounded with mode
+func (g *Group) AverageAmount(mode harvest.RoundingMode) harvest.Money {
+	if g.Count == 0 {
+		return harvest.Money{}.In(g.Amount.Currency())
+	}
+	average, _ := g.Amount.Div(float64(g.Count), mode)
+	return average
+}
+
+// add adds record to the group. The currencies of the group and the record
+// match, as groups are split by currency.
+func (g *Group) add(record pivotRecord) {
+	g.Count++
+	g.Hours += record.hours
+	g.Amount, _ = g.Amount.Add(record.amount)
+}
+
+// Aggregation is the result of an Aggregator. Groups are ordered by their
+// values in the order of the dimensions; names are ordered alphabetically,
+// dates chronologically. Groups with the same values in different currencies
+// are split and ordered by currency.
+//
+// Totals sums up all groups per currency, ordered by currency. Day entries
+// have no amount, their aggregation has a single total.
+type Aggregation struct {
+	Dimensions []Dimension
+	Groups     []*Group
+	Totals     []*Group
+}
+
+// Aggregator groups day entries and expenses of a dataset by a combination of
+// dimensions. Names are resolved from the clients, projects, tasks and users
+// of the dataset.
+type Aggregator struct {
+	Dimensions []Dimension
+	// WeekStart is the first day of the weeks grouped by ByWeek
+	WeekStart harvest.WeekStartDay
+}
+
+// pivotRecord is the common view of day entries and expenses
+type pivotRecord struct {
+	projectId int
+	taskId    int
+	userId    int
+	date      harvest.ShortDate
+	billed    bool
+	closed    bool
+	hours     harvest.Hours
+	amount    harvest.Money
+}
+
+// DayEntries aggregates the day entries of the dataset
+func (a *Aggregator) DayEntries(data *Dataset) (*Aggregation, error) {
+	records := make([]pivotRecord, len(data.DayEntries))
+	for i, entry := range data.DayEntries {
+		records[i] = pivotRecord{
+			projectId: entry.ProjectId,
+			taskId:    entry.TaskId,
+			userId:    entry.UserId,
+			date:      entry.SpentAt,
+			billed:    entry.IsBilled,
+			closed:    entry.IsClosed,
+			hours:     entry.Hours,
+		}
+	}
+	return a.aggregate(data, records)
+}
+
+// Expenses aggregates the expenses of the dataset. Amounts are in the
+// currency of the client of the expense's project.
+func (a *Aggregator) Expenses(data *Dataset) (*Aggregation, error) {
+	records := make([]pivotRecord, len(data.Expenses))
+	for i, expense := range data.Expenses {
+		currency := data.client(data.project(expense.ProjectId).ClientId).Currency
+		records[i] = pivotRecord{
+			projectId: expense.ProjectId,
+			taskId:    expense.TaskId,
+			userId:    expense.UserId,
+			date:      expense.SpentAt,
+			billed:    expense.IsBilled,
+			closed:    expense.IsClosed,
+			amount:    expense.TotalCost.In(currency),
+		}
+	}
+	return a.aggregate(data, records)
+}
+
+func (a *Aggregator) aggregate(data *Dataset, records []pivotRecord) (*Aggregation, error) {
+	for _, dimension := range a.Dimensions {
+		if !dimension.IsValid() {
+			return nil, fmt.Errorf("Unknown dimension: %q", dimension)
+		}
+	}
+	aggregation := &Aggregation{Dimensions: a.Dimensions}
+	groups := make(map[string]*Group)
+	totals := make(map[string]*Group)
+	for _, record := range records {
+		currency := record.amount.Currency()
+		values := make([]Value, len(a.Dimensions))
+		keys := make([]string, len(a.Dimensions)+1)
+		for i, dimension := range a.Dimensions {
+			values[i] = a.value(data, dimension, record)
+			keys[i] = fmt.Sprintf("%d/%s/%s", values[i].ID, values[i].Date, values[i].Name)
+		}
+		keys[len(a.Dimensions)] = currency
+		key := strings.Join(keys, "|")
+		group, ok := groups[key]
+		if !ok {
+			group = &Group{Values: values, Amount: harvest.Money{}.In(currency)}
+			groups[key] = group
+			aggregation.Groups = append(aggregation.Groups, group)
+		}
+		group.add(record)
+		total, ok := totals[currency]
+		if !ok {
+			total = &Group{Amount: harvest.Money{}.In(currency)}
+			totals[currency] = total
+			aggregation.Totals = append(aggregation.Totals, total)
+		}
+		total.add(record)
+	}
+	sort.SliceStable(aggregation.Groups, func(i, j int) bool {
+		x, y := aggregation.Groups[i], aggregation.Groups[j]
+		for k := range x.Values {
+			if x.Values[k].less(y.Values[k]) {
+				return true
+			}
+			if y.Values[k].less(x.Values[k]) {
+				return false
+			}
+		}
+		return x.Amount.Currency() < y.Amount.Currency()
+	})
+	sort.Slice(aggregation.Totals, func(i, j int) bool {
+		return aggregation.Totals[i].Amount.Currency() < aggregation.Totals[j].Amount.Currency()
+	})
+	return aggregation, nil
+}
+
+func (a *Aggregator) value(data *Dataset, dimension Dimension, record pivotRecord) Value {
+	value := Value{Dimension: dimension}
+	switch dimension {
+	case ByClient:
+		client := data.client(data.project(record.projectId).ClientId)
+		value.resolve(client.ID, client.Name)
+	case ByProject:
+		project := data.project(record.projectId)
+		value.resolve(project.ID, project.Name)
+	case ByTask:
+		task := data.task(record.taskId)
+		value.resolve(task.ID, task.Name)
+	case ByUser:
+		user := data.user(record.userId)
+		value.resolve(user.ID, strings.TrimSpace(user.FirstName+" "+user.LastName))
+	case ByDepartment:
+		value.Name = data.user(record.userId).Department
+	case ByDay:
+		value.Date = record.date
+		value.Name = record.date.String()
+	case ByWeek:
+		value.Date = harvest.TimeframeForWeek(record.date, a.WeekStart).StartDate
+		value.Name = value.Date.String()
+	case ByMonth:
+		value.Date = harvest.Date(record.date.Year(), record.date.Month(), 1)
+		value.Name = value.Date.Format("2006-01")
+	case ByBilled:
+		value.Name = "Unbilled"
+		if record.billed {
+			value.ID, value.Name = 1, "Billed"
+		}
+	case ByClosed:
+		value.Name = "Open"
+		if record.closed {
+			value.ID, value.Name = 1, "Closed"
+		}
+	}
+	return value
+}
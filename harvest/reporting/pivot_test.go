@@ -0,0 +1,214 @@
+package reporting
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mitch000001/go-harvest/harvest"
+)
+
+func pivotDataset() *Dataset {
+	data := testDataset()
+	data.Clients = []*harvest.Client{
+		{ID: 1, Name: "Acme", Currency: "EUR"},
+		{ID: 2, Name: "Beta", Currency: "USD"},
+	}
+	data.Projects = []*harvest.Project{
+		{ID: 10, Name: "Website", ClientId: 1},
+		{ID: 20, Name: "App", ClientId: 2},
+	}
+	data.Tasks = []*harvest.Task{
+		{ID: 1, Name: "Development"},
+		{ID: 2, Name: "Design"},
+	}
+	data.DayEntries[0].IsBilled = true
+	data.Expenses = []*harvest.Expense{
+		{ID: 1, ProjectId: 10, UserId: 1, SpentAt: harvest.Date(2015, 1, 5), TotalCost: harvest.MoneyFromFloat(10, "")},
+		{ID: 2, ProjectId: 10, UserId: 2, SpentAt: harvest.Date(2015, 1, 6), TotalCost: harvest.MoneyFromFloat(15, "")},
+		{ID: 3, ProjectId: 20, UserId: 2, SpentAt: harvest.Date(2015, 1, 12), TotalCost: harvest.MoneyFromFloat(30, "")},
+	}
+	return data
+}
+
+func groupNames(aggregation *Aggregation) [][]string {
+	var names [][]string
+	for _, group := range aggregation.Groups {
+		var values []string
+		for _, value := range group.Values {
+			values = append(values, value.Name)
+		}
+		names = append(names, values)
+	}
+	return names
+}
+
+func TestAggregatorDayEntries(t *testing.T) {
+	aggregator := &Aggregator{Dimensions: []Dimension{ByProject, ByBilled}}
+
+	aggregation, err := aggregator.DayEntries(pivotDataset())
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	expectedNames := [][]string{
+		{"App", "Unbilled"},
+		{"Website", "Billed"},
+		{"Website", "Unbilled"},
+	}
+	if !reflect.DeepEqual(expectedNames, groupNames(aggregation)) {
+		t.Logf("Expected groups %v, got %v\n", expectedNames, groupNames(aggregation))
+		t.Fail()
+	}
+
+	website := aggregation.Groups[2]
+	if website.Count != 3 || website.Hours != 14*harvest.Hour {
+		t.Logf("Expected 3 entries with 14 hours, got %d with %s\n", website.Count, website.Hours)
+		t.Fail()
+	}
+	if website.AverageHours() != harvest.HoursFromFloat(14.0/3) {
+		t.Logf("Expected average of %s hours, got %s\n", harvest.HoursFromFloat(14.0/3), website.AverageHours())
+		t.Fail()
+	}
+	if len(aggregation.Totals) != 1 {
+		t.Logf("Expected a single total, got %d\n", len(aggregation.Totals))
+		t.FailNow()
+	}
+	if total := aggregation.Totals[0]; total.Count != 5 || total.Hours != 28*harvest.Hour {
+		t.Logf("Expected total of 5 entries with 28 hours, got %d with %s\n", total.Count, total.Hours)
+		t.Fail()
+	}
+}
+
+func TestAggregatorDayEntriesDates(t *testing.T) {
+	var tests = []struct {
+		dimension Dimension
+		names     [][]string
+	}{
+		{ByWeek, [][]string{{"2015-01-05"}, {"2015-01-12"}, {"2015-01-19"}}},
+		{ByMonth, [][]string{{"2015-01"}}},
+		{ByDepartment, [][]string{{"Dev"}, {"Ops"}}},
+		{ByUser, [][]string{{"Alice Jones"}, {"Bob Smith"}}},
+		{ByTask, [][]string{{"Design"}, {"Development"}}},
+	}
+	for _, test := range tests {
+		aggregator := &Aggregator{Dimensions: []Dimension{test.dimension}, WeekStart: harvest.Monday}
+
+		aggregation, err := aggregator.DayEntries(pivotDataset())
+
+		if err != nil {
+			t.Logf("Expected no error, got %T: %v\n", err, err)
+			t.Fail()
+			continue
+		}
+		if !reflect.DeepEqual(test.names, groupNames(aggregation)) {
+			t.Logf("Expected groups by %s %v, got %v\n", test.dimension, test.names, groupNames(aggregation))
+			t.Fail()
+		}
+	}
+}
+
+func TestAggregatorExpenses(t *testing.T) {
+	aggregator := &Aggregator{Dimensions: []Dimension{ByClient}}
+
+	aggregation, err := aggregator.Expenses(pivotDataset())
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	expectedAmounts := []harvest.Money{harvest.MoneyFromFloat(25, "EUR"), harvest.MoneyFromFloat(30, "USD")}
+	var amounts []harvest.Money
+	for _, group := range aggregation.Groups {
+		amounts = append(amounts, group.Amount)
+	}
+	if !reflect.DeepEqual(expectedAmounts, amounts) {
+		t.Logf("Expected amounts %v, got %v\n", expectedAmounts, amounts)
+		t.Fail()
+	}
+	average := aggregation.Groups[0].AverageAmount(harvest.RoundHalfEven)
+	if average != harvest.MoneyFromFloat(12.5, "EUR") {
+		t.Logf("Expected average of 12.50 EUR, got %s\n", average)
+		t.Fail()
+	}
+}
+
+func TestAggregatorExpensesCurrencies(t *testing.T) {
+	aggregator := &Aggregator{Dimensions: []Dimension{ByUser}}
+
+	aggregation, err := aggregator.Expenses(pivotDataset())
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	expectedNames := [][]string{{"Alice Jones"}, {"Alice Jones"}, {"Bob Smith"}}
+	if !reflect.DeepEqual(expectedNames, groupNames(aggregation)) {
+		t.Logf("Expected groups %v, got %v\n", expectedNames, groupNames(aggregation))
+		t.Fail()
+	}
+
+	var tests = []struct {
+		name     string
+		groups   []*Group
+		expected []harvest.Money
+	}{
+		{
+			"groups", aggregation.Groups,
+			[]harvest.Money{harvest.MoneyFromFloat(15, "EUR"), harvest.MoneyFromFloat(30, "USD"), harvest.MoneyFromFloat(10, "EUR")},
+		},
+		{
+			"totals", aggregation.Totals,
+			[]harvest.Money{harvest.MoneyFromFloat(25, "EUR"), harvest.MoneyFromFloat(30, "USD")},
+		},
+	}
+	for _, test := range tests {
+		var amounts []harvest.Money
+		for _, group := range test.groups {
+			amounts = append(amounts, group.Amount)
+		}
+		if !reflect.DeepEqual(test.expected, amounts) {
+			t.Logf("Expected amounts of %s %v, got %v\n", test.name, test.expected, amounts)
+			t.Fail()
+		}
+	}
+}
+
+func TestAggregatorUnresolved(t *testing.T) {
+	data := pivotDataset()
+	data.DayEntries = append(data.DayEntries, &harvest.DayEntry{ID: 99, ProjectId: 99, UserId: 1, Hours: harvest.Hour, SpentAt: harvest.Date(2015, 1, 5)})
+	aggregator := &Aggregator{Dimensions: []Dimension{ByProject}}
+
+	aggregation, err := aggregator.DayEntries(data)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	expected := Value{Dimension: ByProject, ID: 99, Name: "#99", Unresolved: true}
+	value, _ := aggregation.Groups[0].Value(ByProject)
+	if value != expected {
+		t.Logf("Expected first group to be %+v, got %+v\n", expected, value)
+		t.Fail()
+	}
+
+	if value, _ := aggregation.Groups[1].Value(ByProject); value.Unresolved {
+		t.Logf("Expected known project to be resolved, got %+v\n", value)
+		t.Fail()
+	}
+}
+
+func TestAggregatorErrors(t *testing.T) {
+	aggregator := &Aggregator{Dimensions: []Dimension{"foo"}}
+
+	_, err := aggregator.DayEntries(pivotDataset())
+
+	if err == nil {
+		t.Logf("Expected unknown dimension error, got nil\n")
+		t.Fail()
+	}
+}
@@ -0,0 +1,103 @@
+package reporting
+
+import "github.com/mitch000001/go-harvest/harvest"
+
+// RateSource tells where the hourly rate of an entry was taken from
+type RateSource string
+
+const (
+	// NoRate is used if no hourly rate was found or the project is not billed
+	NoRate                 RateSource = ""
+	RateFromProject        RateSource = "project"
+	RateFromTaskAssignment RateSource = "task_assignment"
+	RateFromTask           RateSource = "task"
+	RateFromUserAssignment RateSource = "user_assignment"
+	RateFromUser           RateSource = "user"
+)
+
+type userKey struct {
+	projectId int
+	userId    int
+}
+
+// RateResolver resolves the hourly rate of day entries the way Harvest does,
+// depending on the BillBy of the project:
+//
+//	Project: the hourly rate of the project
+//	Tasks:   the hourly rate of the task assignment, falling back to the
+//	         default hourly rate of the task
+//	People:  the hourly rate of the user assignment, falling back to the
+//	         default hourly rate of the user
+//
+// Projects billed by none have no rate. Rates are returned in the currency of
+// the project's client.
+type RateResolver struct {
+	data            *Dataset
+	tasks           map[int]*harvest.Task
+	taskAssignments map[taskKey]*harvest.TaskAssignment
+	userAssignments map[userKey]*harvest.UserAssignment
+}
+
+func NewRateResolver(data *Dataset) *RateResolver {
+	r := &RateResolver{
+		data:            data,
+		tasks:           make(map[int]*harvest.Task),
+		taskAssignments: make(map[taskKey]*harvest.TaskAssignment),
+		userAssignments: make(map[userKey]*harvest.UserAssignment),
+	}
+	for _, task := range data.Tasks {
+		r.tasks[task.ID] = task
+	}
+	for _, assignment := range data.TaskAssignments {
+		r.taskAssignments[taskKey{assignment.ProjectId, assignment.TaskId}] = assignment
+	}
+	for _, assignment := range data.UserAssignments {
+		r.userAssignments[userKey{assignment.ProjectId, assignment.UserId}] = assignment
+	}
+	return r
+}
+
+// Rate returns the hourly rate of entry and where it was taken from. If no
+// rate is found, a zero rate and NoRate are returned.
+func (r *RateResolver) Rate(entry *harvest.DayEntry) (harvest.Money, RateSource) {
+	project := r.data.project(entry.ProjectId)
+	rate, source := r.rate(project, entry)
+	if source == NoRate {
+		return harvest.Money{}, NoRate
+	}
+	return rate.In(r.data.client(project.ClientId).Currency), source
+}
+
+func (r *RateResolver) rate(project *harvest.Project, entry *harvest.DayEntry) (harvest.Money, RateSource) {
+	switch project.BillBy {
+	case harvest.BillByProject:
+		if !project.HourlyRate.Value().IsZero() {
+			return project.HourlyRate.Value(), RateFromProject
+		}
+	case harvest.BillByTasks:
+		if assignment, ok := r.taskAssignments[taskKey{entry.ProjectId, entry.TaskId}]; ok && !assignment.HourlyRate.IsZero() {
+			return assignment.HourlyRate, RateFromTaskAssignment
+		}
+		if task, ok := r.tasks[entry.TaskId]; ok && !task.DefaultHourlyRate.IsZero() {
+			return task.DefaultHourlyRate, RateFromTask
+		}
+	case harvest.BillByPeople:
+		if assignment, ok := r.userAssignments[userKey{entry.ProjectId, entry.UserId}]; ok && !assignment.HourlyRate.IsZero() {
+			return assignment.HourlyRate, RateFromUserAssignment
+		}
+		if user := r.data.user(entry.UserId); !user.DefaultHourlyRate.Value().IsZero() {
+			return user.DefaultHourlyRate.Value(), RateFromUser
+		}
+	}
+	return harvest.Money{}, NoRate
+}
+
+// IsBilled returns true if the project of entry is billed at all, i.e. its
+// BillBy is neither none nor empty
+func (r *RateResolver) IsBilled(entry *harvest.DayEntry) bool {
+	switch r.data.project(entry.ProjectId).BillBy {
+	case harvest.BillByProject, harvest.BillByTasks, harvest.BillByPeople:
+		return true
+	}
+	return false
+}
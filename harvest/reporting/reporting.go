@@ -0,0 +1,199 @@
+// Package reporting computes reports like the utilization of users from the
+// resources of a Harvest account.
+package reporting
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mitch000001/go-harvest/harvest"
+)
+
+// Dataset holds the resources a report is computed from
+type Dataset struct {
+	Timeframe       harvest.Timeframe
+	Clients         []*harvest.Client
+	Users           []*harvest.User
+	Projects        []*harvest.Project
+	Tasks           []*harvest.Task
+	TaskAssignments []*harvest.TaskAssignment
+	UserAssignments []*harvest.UserAssignment
+	DayEntries      []*harvest.DayEntry
+	Expenses        []*harvest.Expense
+}
+
+// Load fetches the day entries of all users within timeframe together with
+// the clients, users, projects and tasks. The task and user assignments and
+// the expenses within timeframe are fetched for all active projects and all
+// projects having entries.
+func Load(client *harvest.Harvest, timeframe harvest.Timeframe) (*Dataset, error) {
+	return load(client, harvest.DayEntryQuery{Timeframe: timeframe}, harvest.ExpenseQuery{Timeframe: timeframe}, false)
+}
+
+// LoadUnbilled fetches the same resources as Load, but only the day entries
+// and expenses within timeframe which are not billed yet. The assignments and
+// expenses are fetched for all billable projects as well, as archived
+// projects may have unbilled expenses without any unbilled entries.
+func LoadUnbilled(client *harvest.Harvest, timeframe harvest.Timeframe) (*Dataset, error) {
+	return load(
+		client,
+		harvest.DayEntryQuery{Timeframe: timeframe, OnlyUnbilled: true},
+		harvest.ExpenseQuery{Timeframe: timeframe, OnlyUnbilled: true},
+		true,
+	)
+}
+
+// load fetches the resources of a dataset. If billable is true, the
+// assignments and expenses of all billable projects are fetched in addition
+// to those of active projects and projects having entries.
+func load(client *harvest.Harvest, query harvest.DayEntryQuery, expenseQuery harvest.ExpenseQuery, billable bool) (*Dataset, error) {
+	data := &Dataset{Timeframe: query.Timeframe}
+	if err := client.Clients.All(&data.Clients, nil); err != nil {
+		return nil, err
+	}
+	if err := client.Users.All(&data.Users, nil); err != nil {
+		return nil, err
+	}
+	if err := client.Projects.All(&data.Projects, nil); err != nil {
+		return nil, err
+	}
+	if err := client.Tasks.All(&data.Tasks, nil); err != nil {
+		return nil, err
+	}
+	entries, err := client.DayEntries(query, harvest.FanOutOptions{IncludeInactive: true})
+	if err != nil {
+		return nil, err
+	}
+	data.DayEntries = entries
+	for _, project := range data.Projects {
+		if !project.Active && !data.hasEntries(project.ID) && !(billable && project.Billable) {
+			continue
+		}
+		var taskAssignments []*harvest.TaskAssignment
+		if err := client.Projects.TaskAssignments(project).All(&taskAssignments, nil); err != nil {
+			return nil, err
+		}
+		data.TaskAssignments = append(data.TaskAssignments, taskAssignments...)
+		var userAssignments []*harvest.UserAssignment
+		if err := client.Projects.UserAssignments(project).All(&userAssignments, nil); err != nil {
+			return nil, err
+		}
+		data.UserAssignments = append(data.UserAssignments, userAssignments...)
+		var expenses []*harvest.Expense
+		if err := client.Projects.Expenses(project).Query(&expenses, expenseQuery); err != nil {
+			return nil, err
+		}
+		data.Expenses = append(data.Expenses, expenses...)
+	}
+	return data, nil
+}
+
+func (d *Dataset) hasEntries(projectId int) bool {
+	for _, entry := range d.DayEntries {
+		if entry.ProjectId == projectId {
+			return true
+		}
+	}
+	return false
+}
+
+// user returns the user with id. Unknown users, e.g. deleted ones, are
+// returned with only their ID set.
+func (d *Dataset) user(id int) *harvest.User {
+	for _, user := range d.Users {
+		if user.ID == id {
+			return user
+		}
+	}
+	return &harvest.User{ID: id}
+}
+
+// project returns the project with id. Unknown projects are returned with
+// only their ID set.
+func (d *Dataset) project(id int) *harvest.Project {
+	for _, project := range d.Projects {
+		if project.ID == id {
+			return project
+		}
+	}
+	return &harvest.Project{ID: id}
+}
+
+// task returns the task with id. Unknown tasks are returned with only their
+// ID set.
+func (d *Dataset) task(id int) *harvest.Task {
+	for _, task := range d.Tasks {
+		if task.ID == id {
+			return task
+		}
+	}
+	return &harvest.Task{ID: id}
+}
+
+// client returns the client with id. Unknown clients are returned with only
+// their ID set.
+func (d *Dataset) client(id int) *harvest.Client {
+	for _, client := range d.Clients {
+		if client.ID == id {
+			return client
+		}
+	}
+	return &harvest.Client{ID: id}
+}
+
+// Billability determines whether day entries are billable. An entry is
+// billable if its project is billable and its task is billable within the
+// project.
+type Billability struct {
+	projects map[int]bool
+	tasks    map[taskKey]bool
+}
+
+type taskKey struct {
+	projectId int
+	taskId    int
+}
+
+func NewBillability(projects []*harvest.Project, assignments []*harvest.TaskAssignment) *Billability {
+	b := &Billability{
+		projects: make(map[int]bool),
+		tasks:    make(map[taskKey]bool),
+	}
+	for _, project := range projects {
+		b.projects[project.ID] = project.Billable
+	}
+	for _, assignment := range assignments {
+		b.tasks[taskKey{assignment.ProjectId, assignment.TaskId}] = assignment.Billable
+	}
+	return b
+}
+
+// IsBillable returns true if entry is billable. Entries of unknown projects or
+// tasks are not billable.
+func (b *Billability) IsBillable(entry *harvest.DayEntry) bool {
+	return b.projects[entry.ProjectId] && b.tasks[taskKey{entry.ProjectId, entry.TaskId}]
+}
+
+// timeframeIndex returns the index of the timeframe containing date or -1
+func timeframeIndex(timeframes []harvest.Timeframe, date harvest.ShortDate) int {
+	for i, timeframe := range timeframes {
+		if timeframe.Contains(date) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sortUsers sorts users by last name, first name and ID
+func sortUsers(users []*harvest.User) {
+	sort.SliceStable(users, func(i, j int) bool {
+		a, b := users[i], users[j]
+		if c := strings.Compare(a.LastName, b.LastName); c != 0 {
+			return c < 0
+		}
+		if c := strings.Compare(a.FirstName, b.FirstName); c != 0 {
+			return c < 0
+		}
+		return a.ID < b.ID
+	})
+}
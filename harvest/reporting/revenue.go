@@ -0,0 +1,182 @@
+package reporting
+
+import (
+	"sort"
+
+	"github.com/mitch000001/go-harvest/harvest"
+)
+
+// PricedEntry is a day entry together with its billable amount
+type PricedEntry struct {
+	Entry      *harvest.DayEntry
+	Billable   bool
+	Rate       harvest.Money
+	RateSource RateSource
+	Amount     harvest.Money
+	// MissingRate is true for billable entries without an hourly rate
+	MissingRate bool
+}
+
+// Revenue sums up priced entries. All amounts of a Revenue share a currency.
+type Revenue struct {
+	BillableHours harvest.Hours
+	Amount        harvest.Money
+	// MissingRates is the number of billable entries without an hourly rate
+	MissingRates int
+}
+
+func (r *Revenue) add(entry PricedEntry) {
+	if !entry.Billable {
+		return
+	}
+	r.BillableHours += entry.Entry.Hours
+	if entry.MissingRate {
+		r.MissingRates++
+		return
+	}
+	// the currencies match, as entries are grouped by client
+	r.Amount, _ = r.Amount.Add(entry.Amount)
+}
+
+// ClientRevenue is the revenue of all projects of a client
+type ClientRevenue struct {
+	Client *harvest.Client
+	Revenue
+}
+
+// ProjectRevenue is the revenue of a project
+type ProjectRevenue struct {
+	Project *harvest.Project
+	Revenue
+}
+
+// MonthRevenue is the revenue within a month in a single currency. Month is
+// clipped to the timeframe of the report.
+type MonthRevenue struct {
+	Month    harvest.Timeframe
+	Currency string
+	Revenue
+}
+
+// RevenueReport holds the priced entries within Timeframe and their totals
+// per client, project and month. Clients and projects are sorted by name,
+// months by date and currency.
+type RevenueReport struct {
+	Timeframe harvest.Timeframe
+	Entries   []PricedEntry
+	// MissingRates holds the billable entries without an hourly rate
+	MissingRates []PricedEntry
+	Clients      []ClientRevenue
+	Projects     []ProjectRevenue
+	Months       []MonthRevenue
+}
+
+// RevenueReporter computes RevenueReports
+type RevenueReporter struct {
+	// Rounding is used to round amounts to cents. The zero value rounds half
+	// to even.
+	Rounding harvest.RoundingMode
+}
+
+// Price returns the priced entry for entry. An entry is billable if it is
+// billable according to billability and its project is billed.
+func (r *RevenueReporter) Price(entry *harvest.DayEntry, rates *RateResolver, billability *Billability) PricedEntry {
+	priced := PricedEntry{
+		Entry:    entry,
+		Billable: billability.IsBillable(entry) && rates.IsBilled(entry),
+	}
+	if !priced.Billable {
+		return priced
+	}
+	priced.Rate, priced.RateSource = rates.Rate(entry)
+	if priced.RateSource == NoRate {
+		priced.MissingRate = true
+		return priced
+	}
+	priced.Amount = priced.Rate.MulHours(entry.Hours, 2, r.Rounding)
+	return priced
+}
+
+// Report prices all entries of the dataset within its timeframe
+func (r *RevenueReporter) Report(data *Dataset) *RevenueReport {
+	rates := NewRateResolver(data)
+	billability := NewBillability(data.Projects, data.TaskAssignments)
+	months := data.Timeframe.Split(harvest.PeriodMonth, harvest.Monday)
+
+	type monthKey struct {
+		month    int
+		currency string
+	}
+	clients := make(map[int]*ClientRevenue)
+	projects := make(map[int]*ProjectRevenue)
+	monthRevenues := make(map[monthKey]*MonthRevenue)
+
+	report := &RevenueReport{Timeframe: data.Timeframe}
+	for _, entry := range data.DayEntries {
+		month := timeframeIndex(months, entry.SpentAt)
+		if month == -1 {
+			continue
+		}
+		priced := r.Price(entry, rates, billability)
+		report.Entries = append(report.Entries, priced)
+		if priced.MissingRate {
+			report.MissingRates = append(report.MissingRates, priced)
+		}
+
+		project := data.project(entry.ProjectId)
+		projectRevenue, ok := projects[project.ID]
+		if !ok {
+			projectRevenue = &ProjectRevenue{Project: project}
+			projects[project.ID] = projectRevenue
+		}
+		projectRevenue.add(priced)
+
+		client := data.client(project.ClientId)
+		clientRevenue, ok := clients[client.ID]
+		if !ok {
+			clientRevenue = &ClientRevenue{Client: client}
+			clients[client.ID] = clientRevenue
+		}
+		clientRevenue.add(priced)
+
+		key := monthKey{month, harvest.CurrencyCode(client.Currency)}
+		monthRevenue, ok := monthRevenues[key]
+		if !ok {
+			monthRevenue = &MonthRevenue{Month: months[month], Currency: key.currency}
+			monthRevenues[key] = monthRevenue
+		}
+		monthRevenue.add(priced)
+	}
+
+	for _, client := range clients {
+		report.Clients = append(report.Clients, *client)
+	}
+	sort.Slice(report.Clients, func(i, j int) bool {
+		a, b := report.Clients[i].Client, report.Clients[j].Client
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+	for _, project := range projects {
+		report.Projects = append(report.Projects, *project)
+	}
+	sort.Slice(report.Projects, func(i, j int) bool {
+		a, b := report.Projects[i].Project, report.Projects[j].Project
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+	for _, month := range monthRevenues {
+		report.Months = append(report.Months, *month)
+	}
+	sort.Slice(report.Months, func(i, j int) bool {
+		a, b := report.Months[i], report.Months[j]
+		if !a.Month.StartDate.Equal(b.Month.StartDate) {
+			return a.Month.StartDate.Before(b.Month.StartDate)
+		}
+		return a.Currency < b.Currency
+	})
+	return report
+}
@@ -0,0 +1,140 @@
+package reporting
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mitch000001/go-harvest/harvest"
+)
+
+func testRevenueDataset() *Dataset {
+	rate := func(amount float64) harvest.Money {
+		return harvest.MoneyFromFloat(amount, "")
+	}
+	return &Dataset{
+		Timeframe: harvest.NewTimeframe(2015, 1, 1, 2015, 2, 28),
+		Clients: []*harvest.Client{
+			{ID: 1, Name: "Acme", Currency: "Euro - EUR"},
+			{ID: 2, Name: "Beta", Currency: "USD"},
+		},
+		Users: []*harvest.User{
+			{ID: 1},
+			{ID: 2, DefaultHourlyRate: rate(90).Ptr()},
+		},
+		Projects: []*harvest.Project{
+			{ID: 10, Name: "Tasks", ClientId: 1, Billable: true, BillBy: harvest.BillByTasks},
+			{ID: 20, Name: "People", ClientId: 2, Billable: true, BillBy: harvest.BillByPeople},
+			{ID: 30, Name: "Project", ClientId: 1, Billable: true, BillBy: harvest.BillByProject, HourlyRate: rate(100).Ptr()},
+			{ID: 40, Name: "None", ClientId: 1, Billable: true, BillBy: harvest.BillByNone},
+		},
+		Tasks: []*harvest.Task{
+			{ID: 1, DefaultHourlyRate: rate(50)},
+			{ID: 2},
+		},
+		TaskAssignments: []*harvest.TaskAssignment{
+			{ProjectId: 10, TaskId: 1, Billable: true},
+			{ProjectId: 10, TaskId: 2, Billable: true, HourlyRate: rate(80)},
+			{ProjectId: 10, TaskId: 3, Billable: true},
+			{ProjectId: 20, TaskId: 1, Billable: true},
+			{ProjectId: 30, TaskId: 1, Billable: true},
+			{ProjectId: 40, TaskId: 1, Billable: true},
+		},
+		UserAssignments: []*harvest.UserAssignment{
+			{ProjectId: 20, UserId: 1, HourlyRate: rate(120)},
+		},
+		DayEntries: []*harvest.DayEntry{
+			{ID: 1, ProjectId: 10, TaskId: 1, UserId: 1, Hours: 2 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 5)},
+			{ID: 2, ProjectId: 10, TaskId: 2, UserId: 1, Hours: 90 * harvest.Minute, SpentAt: harvest.Date(2015, 1, 6)},
+			{ID: 3, ProjectId: 20, TaskId: 1, UserId: 1, Hours: 1 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 7)},
+			{ID: 4, ProjectId: 20, TaskId: 1, UserId: 2, Hours: 2 * harvest.Hour, SpentAt: harvest.Date(2015, 2, 2)},
+			{ID: 5, ProjectId: 30, TaskId: 1, UserId: 1, Hours: 30 * harvest.Minute, SpentAt: harvest.Date(2015, 2, 3)},
+			{ID: 6, ProjectId: 40, TaskId: 1, UserId: 1, Hours: 3 * harvest.Hour, SpentAt: harvest.Date(2015, 2, 4)},
+			{ID: 7, ProjectId: 10, TaskId: 3, UserId: 1, Hours: 1 * harvest.Hour, SpentAt: harvest.Date(2015, 2, 5)},
+		},
+	}
+}
+
+func TestRateResolverRate(t *testing.T) {
+	data := testRevenueDataset()
+	resolver := NewRateResolver(data)
+
+	var tests = []struct {
+		entry  *harvest.DayEntry
+		rate   harvest.Money
+		source RateSource
+	}{
+		{data.DayEntries[0], harvest.MoneyFromFloat(50, "EUR"), RateFromTask},
+		{data.DayEntries[1], harvest.MoneyFromFloat(80, "EUR"), RateFromTaskAssignment},
+		{data.DayEntries[2], harvest.MoneyFromFloat(120, "USD"), RateFromUserAssignment},
+		{data.DayEntries[3], harvest.MoneyFromFloat(90, "USD"), RateFromUser},
+		{data.DayEntries[4], harvest.MoneyFromFloat(100, "EUR"), RateFromProject},
+		{data.DayEntries[5], harvest.Money{}, NoRate},
+		{data.DayEntries[6], harvest.Money{}, NoRate},
+	}
+	for _, test := range tests {
+		rate, source := resolver.Rate(test.entry)
+
+		if rate != test.rate || source != test.source {
+			t.Logf("Expected rate of entry %d to equal %v from %q, got %v from %q\n", test.entry.ID, test.rate, test.source, rate, source)
+			t.Fail()
+		}
+	}
+}
+
+func TestRevenueReporterReport(t *testing.T) {
+	report := (&RevenueReporter{}).Report(testRevenueDataset())
+
+	if len(report.Entries) != 7 {
+		t.Logf("Expected 7 priced entries, got %d\n", len(report.Entries))
+		t.Fail()
+	}
+
+	if len(report.MissingRates) != 1 || report.MissingRates[0].Entry.ID != 7 {
+		t.Logf("Expected entry 7 to miss a rate, got %+v\n", report.MissingRates)
+		t.Fail()
+	}
+
+	if billable := report.Entries[5].Billable; billable {
+		t.Logf("Expected entry of project billed by none not to be billable\n")
+		t.Fail()
+	}
+
+	expectedClients := []ClientRevenue{
+		{report.Clients[0].Client, Revenue{5 * harvest.Hour, harvest.MoneyFromFloat(270, "EUR"), 1}},
+		{report.Clients[1].Client, Revenue{3 * harvest.Hour, harvest.MoneyFromFloat(300, "USD"), 0}},
+	}
+
+	if !reflect.DeepEqual(expectedClients, report.Clients) {
+		t.Logf("Expected clients to equal\n%+v\n\tgot\n%+v\n", expectedClients, report.Clients)
+		t.Fail()
+	}
+
+	if report.Clients[0].Client.Name != "Acme" {
+		t.Logf("Expected clients to be sorted by name, got %q first\n", report.Clients[0].Client.Name)
+		t.Fail()
+	}
+
+	var projects []string
+	for _, project := range report.Projects {
+		projects = append(projects, project.Project.Name)
+	}
+
+	if !reflect.DeepEqual([]string{"None", "People", "Project", "Tasks"}, projects) {
+		t.Logf("Expected projects to be sorted by name, got %v\n", projects)
+		t.Fail()
+	}
+
+	january := harvest.NewTimeframe(2015, 1, 1, 2015, 1, 31)
+	february := harvest.NewTimeframe(2015, 2, 1, 2015, 2, 28)
+	expectedMonths := []MonthRevenue{
+		{january, "EUR", Revenue{210 * harvest.Minute, harvest.MoneyFromFloat(220, "EUR"), 0}},
+		{january, "USD", Revenue{1 * harvest.Hour, harvest.MoneyFromFloat(120, "USD"), 0}},
+		{february, "EUR", Revenue{90 * harvest.Minute, harvest.MoneyFromFloat(50, "EUR"), 1}},
+		{february, "USD", Revenue{2 * harvest.Hour, harvest.MoneyFromFloat(180, "USD"), 0}},
+	}
+
+	if !reflect.DeepEqual(expectedMonths, report.Months) {
+		t.Logf("Expected months to equal\n%+v\n\tgot\n%+v\n", expectedMonths, report.Months)
+		t.Fail()
+	}
+}
@@ -0,0 +1,218 @@
+package reporting
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitch000001/go-harvest/harvest"
+	"github.com/mitch000001/go-harvest/harvest/calendar"
+	"github.com/mitch000001/go-harvest/harvest/format"
+)
+
+// LoadTimesheets fetches the active users and their day entries within
+// timeframe. It is a lightweight alternative to Load for reports which need
+// no projects or assignments.
+func LoadTimesheets(client *harvest.Harvest, timeframe harvest.Timeframe) (*Dataset, error) {
+	data := &Dataset{Timeframe: timeframe}
+	var users []*harvest.User
+	if err := client.Users.All(&users, nil); err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		if user.IsActive {
+			data.Users = append(data.Users, user)
+		}
+	}
+	query := harvest.DayEntryQuery{Timeframe: timeframe}
+	entries, err := client.DayEntries(query, harvest.FanOutOptions{})
+	if err != nil {
+		return nil, err
+	}
+	data.DayEntries = entries
+	return data, nil
+}
+
+// MissingDay is a working day with less hours logged than expected
+type MissingDay struct {
+	Date     harvest.ShortDate
+	Expected harvest.Hours
+	Logged   harvest.Hours
+}
+
+// Missing returns the hours not logged
+func (m MissingDay) Missing() harvest.Hours {
+	return m.Expected - m.Logged
+}
+
+// MissingWeek is a week with less hours logged than expected. Week is
+// clipped to the timeframe checked.
+type MissingWeek struct {
+	Week     harvest.Timeframe
+	Expected harvest.Hours
+	Logged   harvest.Hours
+}
+
+// Missing returns the hours not logged
+func (m MissingWeek) Missing() harvest.Hours {
+	return m.Expected - m.Logged
+}
+
+// MissingTime lists the days and weeks a user logged less hours than
+// expected
+type MissingTime struct {
+	User     *harvest.User
+	Expected harvest.Hours
+	Logged   harvest.Hours
+	Days     []MissingDay
+	Weeks    []MissingWeek
+}
+
+// Missing returns the hours not logged within the timeframe checked. Hours
+// logged beyond the expected hours on some days offset missing hours on
+// others, so the result may be zero or negative.
+func (m *MissingTime) Missing() harvest.Hours {
+	return m.Expected - m.Logged
+}
+
+// Reminder returns a plain text reminder for the user, e.g. for an email. A
+// nil formatter formats with the Harvest defaults.
+//
+// The reminder lists the missing days and the missing weeks without a missing
+// day, so that no shortfall is listed twice. The total is the sum of the
+// missing hours listed.
+func (m *MissingTime) Reminder(formatter *format.Formatter) string {
+	if formatter == nil {
+		formatter = format.New(nil)
+	}
+	var reminder strings.Builder
+	name := strings.TrimSpace(m.User.FirstName)
+	if name == "" {
+		name = "there"
+	}
+	var missing harvest.Hours
+	var lines strings.Builder
+	for _, day := range m.Days {
+		missing += day.Missing()
+		fmt.Fprintf(&lines, "  %s: %s of %s hours logged\n", formatter.Date(day.Date), formatter.Hours(day.Logged), formatter.Hours(day.Expected))
+	}
+	for _, week := range m.Weeks {
+		if m.hasMissingDayIn(week.Week) {
+			continue
+		}
+		missing += week.Missing()
+		fmt.Fprintf(&lines, "  %s: %s of %s hours logged\n", formatter.Week(week.Week.StartDate), formatter.Hours(week.Logged), formatter.Hours(week.Expected))
+	}
+	fmt.Fprintf(&reminder, "Hi %s,\n\n", name)
+	fmt.Fprintf(&reminder, "your timesheet is missing %s hours:\n\n", formatter.Hours(missing))
+	reminder.WriteString(lines.String())
+	reminder.WriteString("\nPlease complete your timesheet.\n")
+	return reminder.String()
+}
+
+func (m *MissingTime) hasMissingDayIn(week harvest.Timeframe) bool {
+	for _, day := range m.Days {
+		if week.Contains(day.Date) {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingTimeReport lists all users with missing time within Timeframe,
+// sorted by name
+type MissingTimeReport struct {
+	Timeframe harvest.Timeframe
+	Users     []MissingTime
+}
+
+// MissingTimeChecker finds working days and weeks on which users logged less
+// hours than expected
+type MissingTimeChecker struct {
+	// Calendar provides the expected hours per user and day. If nil, 8 hours
+	// are expected from Monday to Friday.
+	Calendar *calendar.Calendar
+	// WeekStart is the first day of the weeks checked
+	WeekStart harvest.WeekStartDay
+	// Threshold is the part of the expected hours which must be logged, e.g.
+	// 0.9 for 90%. The zero value requires all expected hours.
+	Threshold float64
+	// Today is the last day checked. If zero, the whole timeframe is checked.
+	Today harvest.ShortDate
+}
+
+// NewMissingTimeChecker returns a MissingTimeChecker using the week start day
+// of company
+func NewMissingTimeChecker(company *harvest.Company, calendar *calendar.Calendar) *MissingTimeChecker {
+	return &MissingTimeChecker{Calendar: calendar, WeekStart: company.WeekStartDay}
+}
+
+func (m *MissingTimeChecker) isMissing(expected, logged harvest.Hours) bool {
+	if expected <= 0 {
+		return false
+	}
+	threshold := m.Threshold
+	if threshold <= 0 {
+		threshold = 1
+	}
+	return logged.Float64() < expected.Float64()*threshold
+}
+
+// Check returns the missing time of all active users of the dataset
+func (m *MissingTimeChecker) Check(data *Dataset) *MissingTimeReport {
+	report := &MissingTimeReport{Timeframe: data.Timeframe}
+	timeframe := data.Timeframe
+	if !m.Today.IsZero() && m.Today.Before(timeframe.EndDate) {
+		timeframe.EndDate = m.Today
+	}
+	if timeframe.EndDate.Before(timeframe.StartDate) {
+		return report
+	}
+	weeks := timeframe.Split(harvest.PeriodWeek, m.WeekStart)
+	cal := m.Calendar
+	if cal == nil {
+		cal = calendar.New(calendar.StandardWeek(8 * harvest.Hour))
+	}
+
+	logged := make(map[int]map[harvest.ShortDate]harvest.Hours)
+	for _, entry := range data.DayEntries {
+		if !timeframe.Contains(entry.SpentAt) {
+			continue
+		}
+		if logged[entry.UserId] == nil {
+			logged[entry.UserId] = make(map[harvest.ShortDate]harvest.Hours)
+		}
+		logged[entry.UserId][entry.SpentAt] += entry.Hours
+	}
+
+	var users []*harvest.User
+	for _, user := range data.Users {
+		if user.IsActive {
+			users = append(users, user)
+		}
+	}
+	sortUsers(users)
+	for _, user := range users {
+		missing := MissingTime{User: user}
+		for _, week := range weeks {
+			var weekExpected, weekLogged harvest.Hours
+			for _, day := range week.Days() {
+				expected := cal.ExpectedHours(user.ID, day)
+				hours := logged[user.ID][day]
+				weekExpected += expected
+				weekLogged += hours
+				if m.isMissing(expected, hours) {
+					missing.Days = append(missing.Days, MissingDay{Date: day, Expected: expected, Logged: hours})
+				}
+			}
+			missing.Expected += weekExpected
+			missing.Logged += weekLogged
+			if m.isMissing(weekExpected, weekLogged) {
+				missing.Weeks = append(missing.Weeks, MissingWeek{Week: week, Expected: weekExpected, Logged: weekLogged})
+			}
+		}
+		if len(missing.Days) > 0 || len(missing.Weeks) > 0 {
+			report.Users = append(report.Users, missing)
+		}
+	}
+	return report
+}
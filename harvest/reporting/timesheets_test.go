@@ -0,0 +1,153 @@
+package reporting
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mitch000001/go-harvest/harvest"
+	"github.com/mitch000001/go-harvest/harvest/calendar"
+)
+
+func TestMissingTimeCheckerCheck(t *testing.T) {
+	company := &harvest.Company{WeekStartDay: harvest.Monday}
+	checker := NewMissingTimeChecker(company, calendar.New(calendar.StandardWeek(8*harvest.Hour)))
+	checker.Today = harvest.Date(2015, 1, 6)
+
+	report := checker.Check(testDataset())
+
+	if len(report.Users) != 2 {
+		t.Logf("Expected missing time of 2 users, got %d\n", len(report.Users))
+		t.FailNow()
+	}
+
+	alice := report.Users[0]
+	if alice.User.ID != 2 {
+		t.Logf("Expected first user to be 2, got %d\n", alice.User.ID)
+		t.Fail()
+	}
+	expectedDays := []MissingDay{
+		{Date: harvest.Date(2015, 1, 6), Expected: 8 * harvest.Hour, Logged: 4 * harvest.Hour},
+	}
+	if !reflect.DeepEqual(expectedDays, alice.Days) {
+		t.Logf("Expected missing days to equal %+v, got %+v\n", expectedDays, alice.Days)
+		t.Fail()
+	}
+	expectedWeeks := []MissingWeek{
+		{Week: harvest.NewTimeframe(2015, 1, 5, 2015, 1, 6), Expected: 16 * harvest.Hour, Logged: 12 * harvest.Hour},
+	}
+	if !reflect.DeepEqual(expectedWeeks, alice.Weeks) {
+		t.Logf("Expected missing weeks to equal %+v, got %+v\n", expectedWeeks, alice.Weeks)
+		t.Fail()
+	}
+	if alice.Missing() != 4*harvest.Hour {
+		t.Logf("Expected 4 missing hours, got %s\n", alice.Missing())
+		t.Fail()
+	}
+
+	bob := report.Users[1]
+	if bob.User.ID != 1 {
+		t.Logf("Expected second user to be 1, got %d\n", bob.User.ID)
+		t.Fail()
+	}
+	if len(bob.Days) != 2 {
+		t.Logf("Expected 2 missing days, got %d\n", len(bob.Days))
+		t.Fail()
+	}
+}
+
+func TestMissingTimeCheckerCheckThreshold(t *testing.T) {
+	var tests = []struct {
+		threshold float64
+		today     harvest.ShortDate
+		holidays  []calendar.Holiday
+		users     []int
+	}{
+		{0, harvest.Date(2015, 1, 6), nil, []int{2, 1}},
+		{0.5, harvest.Date(2015, 1, 6), nil, []int{1}},
+		{0, harvest.Date(2015, 1, 6), []calendar.Holiday{{Date: harvest.Date(2015, 1, 6)}}, []int{1}},
+		{0, harvest.Date(2015, 1, 4), nil, nil},
+	}
+	for _, test := range tests {
+		cal := calendar.New(calendar.StandardWeek(8 * harvest.Hour))
+		cal.AddHolidays(test.holidays...)
+		checker := &MissingTimeChecker{Calendar: cal, WeekStart: harvest.Monday, Threshold: test.threshold, Today: test.today}
+
+		report := checker.Check(testDataset())
+
+		var users []int
+		for _, missing := range report.Users {
+			users = append(users, missing.User.ID)
+		}
+		if !reflect.DeepEqual(test.users, users) {
+			t.Logf("Expected users with missing time %v for threshold %g and today %s, got %v\n", test.users, test.threshold, test.today, users)
+			t.Fail()
+		}
+	}
+}
+
+func TestMissingTimeCheckerCheckWithoutCalendar(t *testing.T) {
+	checker := &MissingTimeChecker{Today: harvest.Date(2015, 1, 6)}
+
+	report := checker.Check(testDataset())
+
+	if len(report.Users) != 2 {
+		t.Logf("Expected missing time of 2 users, got %d\n", len(report.Users))
+		t.FailNow()
+	}
+
+	if report.Users[0].Expected != 16*harvest.Hour {
+		t.Logf("Expected 16 hours with the standard week, got %s\n", report.Users[0].Expected)
+		t.Fail()
+	}
+}
+
+func TestMissingTimeReminder(t *testing.T) {
+	missing := &MissingTime{
+		User:     &harvest.User{FirstName: "Alice"},
+		Expected: 16 * harvest.Hour,
+		Logged:   12 * harvest.Hour,
+		Days: []MissingDay{
+			{Date: harvest.Date(2015, 1, 6), Expected: 8 * harvest.Hour, Logged: 4 * harvest.Hour},
+		},
+	}
+
+	reminder := missing.Reminder(nil)
+
+	for _, expected := range []string{"Hi Alice,", "missing 4.00 hours", "06 Jan 2015: 4.00 of 8.00 hours logged"} {
+		if !strings.Contains(reminder, expected) {
+			t.Logf("Expected reminder to contain %q, got %q\n", expected, reminder)
+			t.Fail()
+		}
+	}
+}
+
+func TestMissingTimeReminderOvertime(t *testing.T) {
+	// overtime on monday offsets the missing hours on tuesday
+	missing := &MissingTime{
+		User:     &harvest.User{FirstName: "Alice"},
+		Expected: 24 * harvest.Hour,
+		Logged:   24 * harvest.Hour,
+		Days: []MissingDay{
+			{Date: harvest.Date(2015, 1, 6), Expected: 8 * harvest.Hour, Logged: 4 * harvest.Hour},
+		},
+		Weeks: []MissingWeek{
+			{Week: harvest.NewTimeframe(2015, 1, 5, 2015, 1, 11), Expected: 16 * harvest.Hour, Logged: 14 * harvest.Hour},
+			{Week: harvest.NewTimeframe(2015, 1, 12, 2015, 1, 18), Expected: 8 * harvest.Hour, Logged: 7 * harvest.Hour},
+		},
+	}
+
+	reminder := missing.Reminder(nil)
+
+	for _, expected := range []string{"missing 5.00 hours", "06 Jan 2015: 4.00 of 8.00 hours logged", "Week of 12 Jan 2015: 7.00 of 8.00 hours logged"} {
+		if !strings.Contains(reminder, expected) {
+			t.Logf("Expected reminder to contain %q, got %q\n", expected, reminder)
+			t.Fail()
+		}
+	}
+
+	if strings.Contains(reminder, "Week of 05 Jan 2015") {
+		t.Logf("Expected week with a missing day not to be listed, got %q\n", reminder)
+		t.Fail()
+	}
+}
@@ -0,0 +1,153 @@
+package reporting
+
+import (
+	"sort"
+
+	"github.com/mitch000001/go-harvest/harvest"
+)
+
+// Unbilled sums up the billable work not billed yet. All amounts share the
+// currency of the client. Total is the priced value of the hours plus the
+// expenses.
+type Unbilled struct {
+	Hours    harvest.Hours
+	Amount   harvest.Money
+	Expenses harvest.Money
+	Total    harvest.Money
+	// MissingRates is the number of entries without an hourly rate, their
+	// hours are not priced
+	MissingRates int
+	// Oldest is the date of the oldest unbilled entry or expense
+	Oldest harvest.ShortDate
+}
+
+func newUnbilled(currency string) Unbilled {
+	zero := harvest.Money{}.In(currency)
+	return Unbilled{Amount: zero, Expenses: zero, Total: zero}
+}
+
+func (u *Unbilled) addEntry(entry PricedEntry) {
+	u.Hours += entry.Entry.Hours
+	u.setOldest(entry.Entry.SpentAt)
+	if entry.MissingRate {
+		u.MissingRates++
+		return
+	}
+	// the currencies match, as work is grouped by client
+	u.Amount, _ = u.Amount.Add(entry.Amount)
+	u.Total, _ = u.Total.Add(entry.Amount)
+}
+
+func (u *Unbilled) addExpense(expense *harvest.Expense, cost harvest.Money) {
+	u.setOldest(expense.SpentAt)
+	u.Expenses, _ = u.Expenses.Add(cost)
+	u.Total, _ = u.Total.Add(cost)
+}
+
+func (u *Unbilled) setOldest(date harvest.ShortDate) {
+	if u.Oldest.IsZero() || date.Before(u.Oldest) {
+		u.Oldest = date
+	}
+}
+
+// UnbilledProject is the unbilled work of a project
+type UnbilledProject struct {
+	Project *harvest.Project
+	Unbilled
+}
+
+// UnbilledClient is the unbilled work of all projects of a client
+type UnbilledClient struct {
+	Client *harvest.Client
+	Unbilled
+	Projects []UnbilledProject
+}
+
+// UninvoicedReport lists the clients and projects with unbilled work within
+// Timeframe, sorted by name
+type UninvoicedReport struct {
+	Timeframe harvest.Timeframe
+	Clients   []UnbilledClient
+}
+
+// UninvoicedReporter computes UninvoicedReports. Unbilled work are all
+// billable day entries, see RevenueReporter, and all expenses of billable
+// projects which are not billed yet. Use LoadUnbilled to fetch only the
+// unbilled resources.
+type UninvoicedReporter struct {
+	// Rounding is used to round amounts to cents. The zero value rounds half
+	// to even.
+	Rounding harvest.RoundingMode
+}
+
+// Report returns the unbilled work of the dataset within its timeframe
+func (u *UninvoicedReporter) Report(data *Dataset) *UninvoicedReport {
+	rates := NewRateResolver(data)
+	billability := NewBillability(data.Projects, data.TaskAssignments)
+	pricer := &RevenueReporter{Rounding: u.Rounding}
+
+	clients := make(map[int]*UnbilledClient)
+	projects := make(map[int]*UnbilledProject)
+	unbilled := func(project *harvest.Project) (*UnbilledClient, *UnbilledProject, string) {
+		client := data.client(project.ClientId)
+		currency := harvest.CurrencyCode(client.Currency)
+		clientWork, ok := clients[client.ID]
+		if !ok {
+			clientWork = &UnbilledClient{Client: client, Unbilled: newUnbilled(currency)}
+			clients[client.ID] = clientWork
+		}
+		projectWork, ok := projects[project.ID]
+		if !ok {
+			projectWork = &UnbilledProject{Project: project, Unbilled: newUnbilled(currency)}
+			projects[project.ID] = projectWork
+		}
+		return clientWork, projectWork, currency
+	}
+
+	for _, entry := range data.DayEntries {
+		if entry.IsBilled || !data.Timeframe.Contains(entry.SpentAt) {
+			continue
+		}
+		priced := pricer.Price(entry, rates, billability)
+		if !priced.Billable {
+			continue
+		}
+		clientWork, projectWork, _ := unbilled(data.project(entry.ProjectId))
+		clientWork.addEntry(priced)
+		projectWork.addEntry(priced)
+	}
+	for _, expense := range data.Expenses {
+		project := data.project(expense.ProjectId)
+		if expense.IsBilled || !project.Billable || !data.Timeframe.Contains(expense.SpentAt) {
+			continue
+		}
+		clientWork, projectWork, currency := unbilled(project)
+		cost := expense.TotalCost.In(currency)
+		clientWork.addExpense(expense, cost)
+		projectWork.addExpense(expense, cost)
+	}
+
+	for _, project := range projects {
+		client := clients[data.client(project.Project.ClientId).ID]
+		client.Projects = append(client.Projects, *project)
+	}
+	report := &UninvoicedReport{Timeframe: data.Timeframe}
+	for _, client := range clients {
+		sort.Slice(client.Projects, func(i, j int) bool {
+			a, b := client.Projects[i].Project, client.Projects[j].Project
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.ID < b.ID
+		})
+		report.Clients = append(report.Clients, *client)
+	}
+	sort.Slice(report.Clients, func(i, j int) bool {
+		a, b := report.Clients[i].Client, report.Clients[j].Client
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+	return report
+}
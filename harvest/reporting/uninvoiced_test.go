@@ -0,0 +1,126 @@
+package reporting
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mitch000001/go-harvest/harvest"
+)
+
+func TestUninvoicedReporterReport(t *testing.T) {
+	data := testRevenueDataset()
+	data.DayEntries[0].IsBilled = true
+	data.Expenses = []*harvest.Expense{
+		{ID: 1, ProjectId: 10, SpentAt: harvest.Date(2015, 1, 2), TotalCost: harvest.MoneyFromFloat(20, "")},
+		{ID: 2, ProjectId: 20, SpentAt: harvest.Date(2015, 1, 2), TotalCost: harvest.MoneyFromFloat(40, ""), IsBilled: true},
+		{ID: 3, ProjectId: 40, SpentAt: harvest.Date(2015, 1, 10), TotalCost: harvest.MoneyFromFloat(5, "")},
+		{ID: 4, ProjectId: 40, SpentAt: harvest.Date(2014, 12, 31), TotalCost: harvest.MoneyFromFloat(5, "")},
+	}
+	reporter := &UninvoicedReporter{}
+
+	report := reporter.Report(data)
+
+	if len(report.Clients) != 2 {
+		t.Logf("Expected 2 clients, got %d\n", len(report.Clients))
+		t.FailNow()
+	}
+	if len(report.Clients[0].Projects) != 3 {
+		t.Logf("Expected 3 projects of Acme, got %d\n", len(report.Clients[0].Projects))
+		t.FailNow()
+	}
+
+	eur := func(amount float64) harvest.Money {
+		return harvest.MoneyFromFloat(amount, "EUR")
+	}
+	usd := func(amount float64) harvest.Money {
+		return harvest.MoneyFromFloat(amount, "USD")
+	}
+	var tests = []struct {
+		name     string
+		unbilled Unbilled
+		expected Unbilled
+	}{
+		{
+			"Acme", report.Clients[0].Unbilled,
+			Unbilled{3 * harvest.Hour, eur(170), eur(25), eur(195), 1, harvest.Date(2015, 1, 2)},
+		},
+		{
+			"Beta", report.Clients[1].Unbilled,
+			Unbilled{3 * harvest.Hour, usd(300), usd(0), usd(300), 0, harvest.Date(2015, 1, 7)},
+		},
+		{
+			"None", report.Clients[0].Projects[0].Unbilled,
+			Unbilled{0, eur(0), eur(5), eur(5), 0, harvest.Date(2015, 1, 10)},
+		},
+		{
+			"Project", report.Clients[0].Projects[1].Unbilled,
+			Unbilled{30 * harvest.Minute, eur(50), eur(0), eur(50), 0, harvest.Date(2015, 2, 3)},
+		},
+		{
+			"Tasks", report.Clients[0].Projects[2].Unbilled,
+			Unbilled{150 * harvest.Minute, eur(120), eur(20), eur(140), 1, harvest.Date(2015, 1, 2)},
+		},
+	}
+	for _, test := range tests {
+		if test.unbilled != test.expected {
+			t.Logf("Expected unbilled work of %s to equal %+v, got %+v\n", test.name, test.expected, test.unbilled)
+			t.Fail()
+		}
+	}
+
+	if len(report.Clients[1].Projects) != 1 {
+		t.Logf("Expected 1 project of Beta, got %d\n", len(report.Clients[1].Projects))
+		t.Fail()
+	}
+}
+
+func TestLoadUnbilledInactiveProjectWithExpenses(t *testing.T) {
+	responses := map[string]string{
+		"/clients":              `[{"client":{"id":1,"name":"Acme","currency":"Euro - EUR"}}]`,
+		"/projects":             `[{"project":{"id":40,"name":"Archived","client_id":1,"billable":true}},{"project":{"id":50,"name":"Internal","client_id":1}}]`,
+		"/projects/40/expenses": `[{"expense":{"id":3,"project-id":40,"spent-at":"2015-01-10","total-cost":5}}]`,
+		"/projects/50/expenses": `[{"expense":{"id":4,"project-id":50,"spent-at":"2015-01-10","total-cost":7}}]`,
+	}
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		if strings.HasSuffix(r.URL.Path, "/expenses") && r.URL.Query().Get("only_unbilled") != "yes" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			response = "[]"
+		}
+		fmt.Fprint(w, response)
+	}))
+	defer server.Close()
+	client, err := harvest.New(server.URL, func() harvest.HttpClient { return http.DefaultClient })
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	data, err := LoadUnbilled(client, harvest.NewTimeframe(2015, 1, 1, 2015, 1, 31))
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	if len(data.Expenses) != 1 || data.Expenses[0].ID != 3 {
+		t.Logf("Expected only the expense of the inactive billable project, got %+v (requested %v)\n", data.Expenses, requested)
+		t.FailNow()
+	}
+
+	report := (&UninvoicedReporter{}).Report(data)
+
+	if len(report.Clients) != 1 || report.Clients[0].Expenses != harvest.MoneyFromFloat(5, "EUR") {
+		t.Logf("Expected unbilled expenses of 5 EUR, got %+v\n", report.Clients)
+		t.Fail()
+	}
+}
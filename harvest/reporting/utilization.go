@@ -0,0 +1,151 @@
+package reporting
+
+import (
+	"sort"
+
+	"github.com/mitch000001/go-harvest/harvest"
+	"github.com/mitch000001/go-harvest/harvest/calendar"
+)
+
+// Utilization holds tracked and expected hours
+type Utilization struct {
+	Total       harvest.Hours
+	Billable    harvest.Hours
+	NonBillable harvest.Hours
+	Expected    harvest.Hours
+}
+
+// Percentage returns the billable hours in percent of the expected hours. It
+// returns 0 if no hours are expected.
+func (u Utilization) Percentage() float64 {
+	if u.Expected == 0 {
+		return 0
+	}
+	return float64(u.Billable) / float64(u.Expected) * 100
+}
+
+func (u *Utilization) add(other Utilization) {
+	u.Total += other.Total
+	u.Billable += other.Billable
+	u.NonBillable += other.NonBillable
+	u.Expected += other.Expected
+}
+
+// WeekUtilization is the utilization within a single week. Week is clipped
+// to the timeframe of the report.
+type WeekUtilization struct {
+	Week harvest.Timeframe
+	Utilization
+}
+
+// UserUtilization is the utilization of a single user
+type UserUtilization struct {
+	User *harvest.User
+	Utilization
+	Weeks []WeekUtilization
+}
+
+// DepartmentUtilization is the utilization of all users of a department
+type DepartmentUtilization struct {
+	Department string
+	Utilization
+	Weeks []WeekUtilization
+}
+
+// UtilizationReport holds the utilization within Timeframe for the whole
+// account, per week, per user and per department. Users are sorted by name,
+// departments by department name.
+type UtilizationReport struct {
+	Timeframe   harvest.Timeframe
+	Total       Utilization
+	Weeks       []WeekUtilization
+	Users       []UserUtilization
+	Departments []DepartmentUtilization
+}
+
+// UtilizationReporter computes UtilizationReports
+type UtilizationReporter struct {
+	// Calendar provides the expected hours. If nil, no hours are expected.
+	Calendar *calendar.Calendar
+	// WeekStart is the first day of the weeks of the report
+	WeekStart harvest.WeekStartDay
+}
+
+// Report computes the utilization of all active users and all users with
+// entries within the dataset's timeframe
+func (r *UtilizationReporter) Report(data *Dataset) *UtilizationReport {
+	weeks := data.Timeframe.Split(harvest.PeriodWeek, r.WeekStart)
+	billability := NewBillability(data.Projects, data.TaskAssignments)
+
+	userWeeks := make(map[int][]Utilization)
+	for _, user := range data.Users {
+		if user.IsActive {
+			userWeeks[user.ID] = make([]Utilization, len(weeks))
+		}
+	}
+	for _, entry := range data.DayEntries {
+		week := timeframeIndex(weeks, entry.SpentAt)
+		if week == -1 {
+			continue
+		}
+		if _, ok := userWeeks[entry.UserId]; !ok {
+			userWeeks[entry.UserId] = make([]Utilization, len(weeks))
+		}
+		utilization := &userWeeks[entry.UserId][week]
+		utilization.Total += entry.Hours
+		if billability.IsBillable(entry) {
+			utilization.Billable += entry.Hours
+		} else {
+			utilization.NonBillable += entry.Hours
+		}
+	}
+
+	var users []*harvest.User
+	for userId, utilizations := range userWeeks {
+		users = append(users, data.user(userId))
+		if r.Calendar != nil {
+			for i, week := range weeks {
+				utilizations[i].Expected = r.Calendar.ExpectedHoursIn(userId, week)
+			}
+		}
+	}
+	sortUsers(users)
+
+	report := &UtilizationReport{
+		Timeframe: data.Timeframe,
+		Weeks:     newWeekUtilizations(weeks),
+	}
+	departments := make(map[string]*DepartmentUtilization)
+	for _, user := range users {
+		userUtilization := UserUtilization{User: user, Weeks: newWeekUtilizations(weeks)}
+		department, ok := departments[user.Department]
+		if !ok {
+			department = &DepartmentUtilization{Department: user.Department, Weeks: newWeekUtilizations(weeks)}
+			departments[user.Department] = department
+		}
+		for i, utilization := range userWeeks[user.ID] {
+			userUtilization.Weeks[i].add(utilization)
+			userUtilization.add(utilization)
+			department.Weeks[i].add(utilization)
+			department.add(utilization)
+			report.Weeks[i].add(utilization)
+			report.Total.add(utilization)
+		}
+		report.Users = append(report.Users, userUtilization)
+	}
+	for _, department := range departments {
+		report.Departments = append(report.Departments, *department)
+	}
+	sort.Slice(report.Departments, func(i, j int) bool {
+		return report.Departments[i].Department < report.Departments[j].Department
+	})
+	return report
+}
+
+func newWeekUtilizations(weeks []harvest.Timeframe) []WeekUtilization {
+	utilizations := make([]WeekUtilization, len(weeks))
+	for i, week := range weeks {
+		utilizations[i].Week = week
+	}
+	return utilizations
+}
@@ -0,0 +1,131 @@
+package reporting
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mitch000001/go-harvest/harvest"
+	"github.com/mitch000001/go-harvest/harvest/calendar"
+)
+
+func testDataset() *Dataset {
+	return &Dataset{
+		Timeframe: harvest.NewTimeframe(2015, 1, 5, 2015, 1, 18),
+		Users: []*harvest.User{
+			{ID: 1, FirstName: "Bob", LastName: "Smith", Department: "Ops", IsActive: true},
+			{ID: 2, FirstName: "Alice", LastName: "Jones", Department: "Dev", IsActive: true},
+			{ID: 3, FirstName: "Carl", LastName: "Inactive", IsActive: false},
+		},
+		Projects: []*harvest.Project{
+			{ID: 10, Billable: true},
+			{ID: 20, Billable: false},
+		},
+		TaskAssignments: []*harvest.TaskAssignment{
+			{ProjectId: 10, TaskId: 1, Billable: true},
+			{ProjectId: 10, TaskId: 2, Billable: false},
+			{ProjectId: 20, TaskId: 1, Billable: true},
+		},
+		DayEntries: []*harvest.DayEntry{
+			{ID: 1, UserId: 2, ProjectId: 10, TaskId: 1, Hours: 8 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 5)},
+			{ID: 2, UserId: 2, ProjectId: 10, TaskId: 2, Hours: 4 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 6)},
+			{ID: 3, UserId: 2, ProjectId: 20, TaskId: 1, Hours: 6 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 13)},
+			{ID: 4, UserId: 1, ProjectId: 10, TaskId: 1, Hours: 5 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 12)},
+			{ID: 5, UserId: 1, ProjectId: 10, TaskId: 1, Hours: 5 * harvest.Hour, SpentAt: harvest.Date(2015, 1, 19)},
+		},
+	}
+}
+
+func TestBillabilityIsBillable(t *testing.T) {
+	data := testDataset()
+	billability := NewBillability(data.Projects, data.TaskAssignments)
+
+	var tests = []struct {
+		entry    *harvest.DayEntry
+		billable bool
+	}{
+		{&harvest.DayEntry{ProjectId: 10, TaskId: 1}, true},
+		{&harvest.DayEntry{ProjectId: 10, TaskId: 2}, false},
+		{&harvest.DayEntry{ProjectId: 20, TaskId: 1}, false},
+		{&harvest.DayEntry{ProjectId: 30, TaskId: 1}, false},
+	}
+	for _, test := range tests {
+		billable := billability.IsBillable(test.entry)
+
+		if billable != test.billable {
+			t.Logf("Expected billable of %+v to be %t, got %t\n", test.entry, test.billable, billable)
+			t.Fail()
+		}
+	}
+}
+
+func TestUtilizationReporterReport(t *testing.T) {
+	reporter := &UtilizationReporter{
+		Calendar:  calendar.New(calendar.StandardWeek(8 * harvest.Hour)),
+		WeekStart: harvest.Monday,
+	}
+
+	report := reporter.Report(testDataset())
+
+	expectedTotal := Utilization{Total: 23 * harvest.Hour, Billable: 13 * harvest.Hour, NonBillable: 10 * harvest.Hour, Expected: 160 * harvest.Hour}
+
+	if !reflect.DeepEqual(expectedTotal, report.Total) {
+		t.Logf("Expected total to equal %+v, got %+v\n", expectedTotal, report.Total)
+		t.Fail()
+	}
+
+	if len(report.Users) != 2 {
+		t.Logf("Expected utilization of 2 users, got %d\n", len(report.Users))
+		t.FailNow()
+	}
+
+	alice := report.Users[0]
+
+	if alice.User.ID != 2 {
+		t.Logf("Expected users to be sorted by name, got %+v first\n", alice.User)
+		t.Fail()
+	}
+
+	expectedWeeks := []WeekUtilization{
+		{harvest.NewTimeframe(2015, 1, 5, 2015, 1, 11), Utilization{12 * harvest.Hour, 8 * harvest.Hour, 4 * harvest.Hour, 40 * harvest.Hour}},
+		{harvest.NewTimeframe(2015, 1, 12, 2015, 1, 18), Utilization{6 * harvest.Hour, 0, 6 * harvest.Hour, 40 * harvest.Hour}},
+	}
+
+	if !reflect.DeepEqual(expectedWeeks, alice.Weeks) {
+		t.Logf("Expected weeks to equal\n%+v\n\tgot\n%+v\n", expectedWeeks, alice.Weeks)
+		t.Fail()
+	}
+
+	if percentage := alice.Percentage(); percentage != 10 {
+		t.Logf("Expected utilization of 10%%, got %v\n", percentage)
+		t.Fail()
+	}
+
+	var departments []string
+	for _, department := range report.Departments {
+		departments = append(departments, department.Department)
+	}
+
+	if !reflect.DeepEqual([]string{"Dev", "Ops"}, departments) {
+		t.Logf("Expected departments Dev and Ops, got %v\n", departments)
+		t.Fail()
+	}
+
+	if billable := report.Departments[1].Weeks[1].Billable; billable != 5*harvest.Hour {
+		t.Logf("Expected 5 billable hours for Ops in the second week, got %v\n", billable)
+		t.Fail()
+	}
+
+	if billable := report.Weeks[1].Billable; billable != 5*harvest.Hour {
+		t.Logf("Expected 5 billable hours in the second week, got %v\n", billable)
+		t.Fail()
+	}
+}
+
+func TestUtilizationPercentage(t *testing.T) {
+	utilization := Utilization{Billable: 8 * harvest.Hour}
+
+	if percentage := utilization.Percentage(); percentage != 0 {
+		t.Logf("Expected 0%% without expected hours, got %v\n", percentage)
+		t.Fail()
+	}
+}
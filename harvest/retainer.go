@@ -0,0 +1,66 @@
+package harvest
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Retainer struct {
+	ID       int    `json:"id"`
+	ClientId int    `json:"client-id"`
+	Subject  string `json:"subject"`
+	Notes    string `json:"notes"`
+	Currency string `json:"currency"`
+	// the total amount funded by all funding invoices
+	FundedAmount Money `json:"funded-amount"`
+	// the total amount drawn down by invoices billed against the retainer
+	UsedAmount Money `json:"used-amount"`
+	// the prepaid amount still available
+	Balance   Money     `json:"balance"`
+	UpdatedAt time.Time `json:"updated-at"`
+	CreatedAt time.Time `json:"created-at"`
+	// JSON attributes not modeled by Retainer
+	Extra ExtraFields `json:"-"`
+}
+
+func (r *Retainer) Id() int {
+	return r.ID
+}
+
+func (r *Retainer) SetId(id int) {
+	r.ID = id
+}
+
+func (r *Retainer) ExtraFields() *ExtraFields {
+	return &r.Extra
+}
+
+func (r *Retainer) Type() string {
+	return "retainer"
+}
+
+// UnmarshalJSON unmarshals the retainer and sets its currency on all amounts
+func (r *Retainer) UnmarshalJSON(data []byte) error {
+	type retainer Retainer
+	err := json.Unmarshal(data, (*retainer)(r))
+	if err != nil {
+		return err
+	}
+	r.FundedAmount = r.FundedAmount.In(r.Currency)
+	r.UsedAmount = r.UsedAmount.In(r.Currency)
+	r.Balance = r.Balance.In(r.Currency)
+	return nil
+}
+
+// RetainerDrawdown represents an amount billed against the balance of a
+// retainer
+type RetainerDrawdown struct {
+	ID         int `json:"id"`
+	RetainerId int `json:"retainer-id"`
+	// the invoice which used the retainer balance
+	InvoiceId int       `json:"invoice-id"`
+	Amount    Money     `json:"amount"`
+	DrawnAt   ShortDate `json:"drawn-at"`
+	UpdatedAt time.Time `json:"updated-at"`
+	CreatedAt time.Time `json:"created-at"`
+}
@@ -0,0 +1,55 @@
+package harvest
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// RetainerService provides read access to the retainers of an account.
+type RetainerService struct {
+	endpoint CrudEndpoint
+	provider CrudEndpointProvider
+}
+
+func NewRetainerService(endpoint CrudEndpoint, provider CrudEndpointProvider) *RetainerService {
+	return &RetainerService{endpoint: endpoint, provider: provider}
+}
+
+func (s *RetainerService) All(retainers *[]*Retainer, params url.Values) error {
+	return s.endpoint.All(retainers, params)
+}
+
+func (s *RetainerService) Find(id int, retainer *Retainer, params url.Values) error {
+	return s.endpoint.Find(id, retainer, params)
+}
+
+// FundingInvoices returns an InvoiceService scoped to the invoices which
+// funded the given retainer
+func (s *RetainerService) FundingInvoices(retainer *Retainer) *InvoiceService {
+	id := retainer.Id()
+	retainerPath := s.endpoint.Path()
+	path := fmt.Sprintf("%s/%d/invoices", retainerPath, id)
+	endpoint := s.provider.CrudEndpoint(path)
+	return NewInvoiceService(endpoint, s.provider)
+}
+
+// Drawdowns returns a RetainerDrawdownService for the given retainer
+func (s *RetainerService) Drawdowns(retainer *Retainer) *RetainerDrawdownService {
+	id := retainer.Id()
+	retainerPath := s.endpoint.Path()
+	path := fmt.Sprintf("%s/%d/drawdowns", retainerPath, id)
+	endpoint := s.provider.CrudEndpoint(path)
+	return NewRetainerDrawdownService(endpoint)
+}
+
+type RetainerDrawdownService struct {
+	endpoint AllEndpoint
+}
+
+func NewRetainerDrawdownService(endpoint AllEndpoint) *RetainerDrawdownService {
+	return &RetainerDrawdownService{endpoint: endpoint}
+}
+
+func (s *RetainerDrawdownService) All(drawdowns *[]*RetainerDrawdown, params url.Values) error {
+	return s.endpoint.All(drawdowns, params)
+}
@@ -0,0 +1,127 @@
+package harvest
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestRetainerServiceAll(t *testing.T) {
+	called := false
+	expectedParams := url.Values{"foo": []string{"bar"}}
+	testData := apiWrapperTestData{
+		expectedParams:       expectedParams,
+		expectedDataType:     reflect.TypeOf(&[]*Retainer{}),
+		expectedErrorMessage: "ERR",
+	}
+	service := NewRetainerService(testApiAllWrapper(&testData, &called), nil)
+
+	err := service.All(&[]*Retainer{}, expectedParams)
+
+	if !called {
+		t.Logf("Expected Api.All method to have been called, was not.\n")
+		t.Fail()
+	}
+
+	if errors := testData.getErrors(); errors != "" {
+		t.Logf("Found errors:\n%s", errors)
+		t.Fail()
+	}
+
+	if err == nil || err.Error() != "ERR" {
+		t.Logf("Expected error with message 'ERR', got %v\n", err)
+		t.Fail()
+	}
+}
+
+func TestRetainerServiceFind(t *testing.T) {
+	called := false
+	expectedParams := url.Values{"foo": []string{"bar"}}
+	testData := apiWrapperTestData{
+		expectedParams:       expectedParams,
+		expectedIdType:       reflect.TypeOf(12),
+		expectedDataType:     reflect.TypeOf(&Retainer{}),
+		expectedErrorMessage: "ERR",
+	}
+	service := NewRetainerService(testApiFindWrapper(&testData, &called), nil)
+
+	err := service.Find(12, &Retainer{}, expectedParams)
+
+	if !called {
+		t.Logf("Expected Api.Find method to have been called, was not.\n")
+		t.Fail()
+	}
+
+	if errors := testData.getErrors(); errors != "" {
+		t.Logf("Found errors:\n%s", errors)
+		t.Fail()
+	}
+
+	if err == nil || err.Error() != "ERR" {
+		t.Logf("Expected error with message 'ERR', got %v\n", err)
+		t.Fail()
+	}
+}
+
+func TestRetainerServiceFundingInvoices(t *testing.T) {
+	called := false
+	testData := apiWrapperTestData{
+		expectedDataType:     reflect.TypeOf(&[]*Invoice{}),
+		expectedErrorMessage: "ERR",
+	}
+	provider := &testCrudEndpointProvider{endpoint: testApiAllWrapper(&testData, &called)}
+	service := NewRetainerService(&JsonApi{path: "retainers"}, provider)
+
+	invoiceService := service.FundingInvoices(&Retainer{ID: 3})
+
+	if provider.path != "retainers/3/invoices" {
+		t.Logf("Expected path to equal 'retainers/3/invoices', got %q\n", provider.path)
+		t.Fail()
+	}
+
+	invoiceService.All(&[]*Invoice{}, nil)
+
+	if !called {
+		t.Logf("Expected Api.All method to have been called, was not.\n")
+		t.Fail()
+	}
+
+	if errors := testData.getErrors(); errors != "" {
+		t.Logf("Found errors:\n%s", errors)
+		t.Fail()
+	}
+}
+
+func TestRetainerServiceDrawdowns(t *testing.T) {
+	called := false
+	testData := apiWrapperTestData{
+		expectedDataType:     reflect.TypeOf(&[]*RetainerDrawdown{}),
+		expectedErrorMessage: "ERR",
+	}
+	provider := &testCrudEndpointProvider{endpoint: testApiAllWrapper(&testData, &called)}
+	service := NewRetainerService(&JsonApi{path: "retainers"}, provider)
+
+	drawdownService := service.Drawdowns(&Retainer{ID: 3})
+
+	if provider.path != "retainers/3/drawdowns" {
+		t.Logf("Expected path to equal 'retainers/3/drawdowns', got %q\n", provider.path)
+		t.Fail()
+	}
+
+	err := drawdownService.All(&[]*RetainerDrawdown{}, nil)
+
+	if !called {
+		t.Logf("Expected Api.All method to have been called, was not.\n")
+		t.Fail()
+	}
+
+	if errors := testData.getErrors(); errors != "" {
+		t.Logf("Found errors:\n%s", errors)
+		t.Fail()
+	}
+
+	if err == nil || err.Error() != "ERR" {
+		t.Logf("Expected error with message 'ERR', got %v\n", err)
+		t.Fail()
+	}
+}
@@ -8,14 +8,16 @@ type Task struct {
 	// If true task will be added as billable upon assigning it to a project
 	BillableByDefault bool `json:"billable-by-default"`
 	// False if hours can be recorded against this task.  True if task is archived -->
-	Deactivated       bool `json:"deactivated"`
-	DefaultHourlyRate int  `json:"default-hourly-rate"`
-	ID                int  `json:"id"`
+	Deactivated       bool  `json:"deactivated"`
+	DefaultHourlyRate Money `json:"default-hourly-rate"`
+	ID                int   `json:"id"`
 	// If true task is added to new projects by default -->
 	IsDefault bool      `json:"is-default"`
 	Name      string    `json:"name"`
 	CreatedAt time.Time `json:"created-at,omitempty"`
 	UpdatedAt time.Time `json:"updated-at,omitempty"`
+	// JSON attributes not modeled by Task
+	Extra ExtraFields `json:"-"`
 }
 
 func (t *Task) Type() string {
@@ -29,3 +31,7 @@ func (t *Task) Id() int {
 func (t *Task) SetId(id int) {
 	t.ID = id
 }
+
+func (t *Task) ExtraFields() *ExtraFields {
+	return &t.Extra
+}
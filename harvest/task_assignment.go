@@ -15,9 +15,11 @@ type TaskAssignment struct {
 	// The budget (if present) for the task in project
 	Budget float64 `json:"budget"`
 	// The hourly rate (if present) for the task in project
-	HourlyRate float64   `json:"hourly-rate"`
+	HourlyRate Money     `json:"hourly-rate"`
 	UpdatedAt  time.Time `json:"updated_at,omitempty"`
 	CreatedAt  time.Time `json:"created_at,omitempty"`
+	// JSON attributes not modeled by TaskAssignment
+	Extra ExtraFields `json:"-"`
 }
 
 func (t *TaskAssignment) Id() int {
@@ -28,6 +30,10 @@ func (t *TaskAssignment) SetId(id int) {
 	t.ID = id
 }
 
+func (t *TaskAssignment) ExtraFields() *ExtraFields {
+	return &t.Extra
+}
+
 func (t *TaskAssignment) Type() string {
 	return "task-assignment"
 }
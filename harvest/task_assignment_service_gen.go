@@ -17,22 +17,37 @@ func NewTaskAssignmentService(endpoint CrudEndpoint) *TaskAssignmentService {
 	return &service
 }
 
+// Typed returns the typed service for taskassignments
+func (s *TaskAssignmentService) Typed() *Service[TaskAssignment, *TaskAssignment] {
+	return NewService[TaskAssignment](s.endpoint)
+}
+
 func (s *TaskAssignmentService) All(taskassignments *[]*TaskAssignment, params url.Values) error {
-	return s.endpoint.All(taskassignments, params)
+	all, err := s.Typed().All(params)
+	if all != nil {
+		*taskassignments = all
+	}
+	return err
 }
 
 func (s *TaskAssignmentService) Find(id int, taskassignment *TaskAssignment, params url.Values) error {
-	return s.endpoint.Find(id, taskassignment, params)
+	found, err := s.Typed().Find(id, params)
+	if found != nil {
+		*taskassignment = *found
+	}
+	return err
 }
 
 func (s *TaskAssignmentService) Create(taskassignment *TaskAssignment) error {
-	return s.endpoint.Create(taskassignment)
+	return s.Typed().Create(taskassignment)
 }
 
-func (s *TaskAssignmentService) Update(taskassignment *TaskAssignment) error {
-	return s.endpoint.Update(taskassignment)
+// Update updates the given taskassignment. If fields are provided, only these
+// fields are sent to the API, see NewPatch.
+func (s *TaskAssignmentService) Update(taskassignment *TaskAssignment, fields ...string) error {
+	return s.Typed().Update(taskassignment, fields...)
 }
 
 func (s *TaskAssignmentService) Delete(taskassignment *TaskAssignment) error {
-	return s.endpoint.Delete(taskassignment)
+	return s.Typed().Delete(taskassignment)
 }
@@ -22,22 +22,37 @@ func NewTaskService(endpoint CrudEndpoint, processor RequestProcessor) *TaskServ
 	return &service
 }
 
+// Typed returns the typed service for tasks
+func (s *TaskService) Typed() *Service[Task, *Task] {
+	return NewService[Task](s.endpoint)
+}
+
 func (s *TaskService) All(tasks *[]*Task, params url.Values) error {
-	return s.endpoint.All(tasks, params)
+	all, err := s.Typed().All(params)
+	if all != nil {
+		*tasks = all
+	}
+	return err
 }
 
 func (s *TaskService) Find(id int, task *Task, params url.Values) error {
-	return s.endpoint.Find(id, task, params)
+	found, err := s.Typed().Find(id, params)
+	if found != nil {
+		*task = *found
+	}
+	return err
 }
 
 func (s *TaskService) Create(task *Task) error {
-	return s.endpoint.Create(task)
+	return s.Typed().Create(task)
 }
 
-func (s *TaskService) Update(task *Task) error {
-	return s.endpoint.Update(task)
+// Update updates the given task. If fields are provided, only these
+// fields are sent to the API, see NewPatch.
+func (s *TaskService) Update(task *Task, fields ...string) error {
+	return s.Typed().Update(task, fields...)
 }
 
 func (s *TaskService) Delete(task *Task) error {
-	return s.endpoint.Delete(task)
+	return s.Typed().Delete(task)
 }
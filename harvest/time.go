@@ -9,53 +9,188 @@ import (
 	"time"
 )
 
+// ShortDate is a calendar date without time of day and timezone, like the
+// spent-at date of a day entry. Use In to get the time.Time at which the date
+// starts within a given timezone.
+//
+// The zero value is not a valid date, see IsZero.
 type ShortDate struct {
-	time.Time
+	year  int
+	month time.Month
+	day   int
 }
 
-func NewShortDate(date time.Time) ShortDate {
-	return Date(date.Year(), date.Month(), date.Day(), date.Location())
+// NewShortDate returns the calendar date of t within the location of t. Use
+// t.In to get the date within another timezone.
+func NewShortDate(t time.Time) ShortDate {
+	year, month, day := t.Date()
+	return ShortDate{year: year, month: month, day: day}
 }
 
-func Date(year int, month time.Month, day int, location *time.Location) ShortDate {
-	return ShortDate{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+// Date returns the calendar date for year, month and day. Values out of range
+// are normalized like time.Date does, e.g. January 32 becomes February 1.
+func Date(year int, month time.Month, day int) ShortDate {
+	return NewShortDate(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
 }
 
-func (date *ShortDate) MarshalJSON() ([]byte, error) {
+// Today returns the current date within location
+func Today(location *time.Location) ShortDate {
+	return NewShortDate(time.Now().In(location))
+}
+
+// ParseShortDate parses a date formatted as 2006-01-02
+func ParseShortDate(value string) (ShortDate, error) {
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return ShortDate{}, err
+	}
+	return NewShortDate(t), nil
+}
+
+func (date ShortDate) Year() int {
+	return date.year
+}
+
+func (date ShortDate) Month() time.Month {
+	return date.month
+}
+
+func (date ShortDate) Day() int {
+	return date.day
+}
+
+// Date returns the year, month and day of date
+func (date ShortDate) Date() (int, time.Month, int) {
+	return date.year, date.month, date.day
+}
+
+func (date ShortDate) Weekday() time.Weekday {
+	return date.utc().Weekday()
+}
+
+func (date ShortDate) ISOWeek() (int, int) {
+	return date.utc().ISOWeek()
+}
+
+// IsZero returns true for the zero ShortDate
+func (date ShortDate) IsZero() bool {
+	return date == ShortDate{}
+}
+
+// In returns the time at which date starts within location. The day may be
+// shorter or longer than 24 hours due to daylight saving time transitions.
+func (date ShortDate) In(location *time.Location) time.Time {
+	return time.Date(date.year, date.month, date.day, 0, 0, 0, 0, location)
+}
+
+// utc returns the start of date in UTC, which is free of daylight saving time
+// transitions and thus safe for calendar arithmetic
+func (date ShortDate) utc() time.Time {
+	return date.In(time.UTC)
+}
+
+// AddDate returns the date years, months and days after date, normalized like
+// time.Time.AddDate does
+func (date ShortDate) AddDate(years int, months int, days int) ShortDate {
+	return NewShortDate(date.utc().AddDate(years, months, days))
+}
+
+// AddDays returns the date days days after date. days may be negative.
+func (date ShortDate) AddDays(days int) ShortDate {
+	return date.AddDate(0, 0, days)
+}
+
+func (date ShortDate) Before(other ShortDate) bool {
+	return compareDates(date, other) < 0
+}
+
+func (date ShortDate) After(other ShortDate) bool {
+	return compareDates(date, other) > 0
+}
+
+func (date ShortDate) Equal(other ShortDate) bool {
+	return date == other
+}
+
+// Format formats date with the time layout. Elements of the layout referring
+// to the time of day or timezone render midnight UTC.
+func (date ShortDate) Format(layout string) string {
+	return date.utc().Format(layout)
+}
+
+// compareDates compares the calendar dates of a and b. It returns -1, 0 or +1
+// if a is before, equal to or after b.
+func compareDates(a, b ShortDate) int {
+	switch {
+	case a.year != b.year:
+		return signum(a.year - b.year)
+	case a.month != b.month:
+		return signum(int(a.month - b.month))
+	}
+	return signum(a.day - b.day)
+}
+
+func signum(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	}
+	return 0
+}
+
+func (date ShortDate) MarshalJSON() ([]byte, error) {
 	if date.IsZero() {
 		return json.Marshal("")
 	}
-	return json.Marshal(date.Format("2006-01-02"))
+	return json.Marshal(date.String())
 }
 
+// UnmarshalJSON accepts dates formatted as 2006-01-02. Empty strings and null
+// result in the zero ShortDate.
 func (date *ShortDate) UnmarshalJSON(data []byte) error {
-	unquotedData, _ := strconv.Unquote(string(data))
-	time, err := time.Parse("2006-01-02", unquotedData)
-	date.Time = time
-	return err
+	if string(data) == "null" {
+		*date = ShortDate{}
+		return nil
+	}
+	unquotedData, err := strconv.Unquote(string(data))
+	if err != nil {
+		return err
+	}
+	if unquotedData == "" {
+		*date = ShortDate{}
+		return nil
+	}
+	parsed, err := ParseShortDate(unquotedData)
+	if err != nil {
+		return err
+	}
+	*date = parsed
+	return nil
 }
 
-func (date *ShortDate) String() string {
+func (date ShortDate) String() string {
 	return date.Format("2006-01-02")
 }
 
-func (s *ShortDate) MarshalText() ([]byte, error) {
-	return []byte(s.Format("2006-01-02")), nil
+func (s ShortDate) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
 }
 
 func (s *ShortDate) UnmarshalText(text []byte) error {
-	time, err := time.Parse("2006-01-02", string(text))
+	date, err := ParseShortDate(string(text))
 	if err != nil {
 		return err
 	}
-	*s = ShortDate{time}
+	*s = date
 	return nil
 }
 
-func NewTimeframe(startYear int, startMonth time.Month, startDay int, endYear int, endMonth time.Month, endDay int, location *time.Location) Timeframe {
+func NewTimeframe(startYear int, startMonth time.Month, startDay int, endYear int, endMonth time.Month, endDay int) Timeframe {
 	return Timeframe{
-		StartDate: Date(startYear, startMonth, startDay, location),
-		EndDate:   Date(endYear, endMonth, endDay, location),
+		StartDate: Date(startYear, startMonth, startDay),
+		EndDate:   Date(endYear, endMonth, endDay),
 	}
 }
 
@@ -64,11 +199,10 @@ type Timeframe struct {
 	EndDate   ShortDate
 }
 
-// TimeframeFromDate returns a Timeframe with the StartDate set to date and the EndDate set to today.
-// The EndDate will use the same timezone location as provided in StartDate
-func TimeframeFromDate(date ShortDate) Timeframe {
-	endDate := NewShortDate(time.Now().In(date.Location()))
-	return Timeframe{date, endDate}
+// TimeframeFromDate returns a Timeframe with the StartDate set to date and the
+// EndDate set to today within location.
+func TimeframeFromDate(date ShortDate, location *time.Location) Timeframe {
+	return Timeframe{date, Today(location)}
 }
 
 func TimeframeFromQuery(params url.Values) (Timeframe, error) {
@@ -78,23 +212,24 @@ func TimeframeFromQuery(params url.Values) (Timeframe, error) {
 		return Timeframe{}, fmt.Errorf("'from' and/or 'to' must be set")
 	}
 	startTime, err1 := time.Parse("20060102", from)
-	startDate := ShortDate{startTime}
 	endTime, err2 := time.Parse("20060102", to)
-	endDate := ShortDate{endTime}
 	if err1 != nil || err2 != nil {
 		return Timeframe{}, fmt.Errorf("Malformed query params")
 	}
-	return Timeframe{StartDate: startDate, EndDate: endDate}, nil
+	return Timeframe{StartDate: NewShortDate(startTime), EndDate: NewShortDate(endTime)}, nil
 }
 
+// IsInTimeframe returns true if date is within the timeframe, including its
+// start and end date
 func (tf Timeframe) IsInTimeframe(date ShortDate) bool {
-	if tf.StartDate.Truncate(24 * time.Hour).Equal(date.Truncate(24 * time.Hour)) {
-		return true
-	}
-	if tf.EndDate.Truncate(24 * time.Hour).Equal(date.Truncate(24 * time.Hour)) {
-		return true
-	}
-	return tf.StartDate.Before(date.Time) && tf.EndDate.After(date.Time)
+	return tf.Contains(date)
+}
+
+// In returns the time range covered by the timeframe within location: the
+// start of StartDate and the start of the day after EndDate, which is
+// excluded from the range.
+func (tf Timeframe) In(location *time.Location) (time.Time, time.Time) {
+	return tf.StartDate.In(location), tf.EndDate.AddDays(1).In(location)
 }
 
 func (tf *Timeframe) ToQuery() url.Values {
@@ -108,7 +243,7 @@ func (tf *Timeframe) MarshalJSON() ([]byte, error) {
 	if tf.StartDate.IsZero() || tf.EndDate.IsZero() {
 		return json.Marshal("")
 	}
-	return json.Marshal(fmt.Sprintf("%s,%s", tf.StartDate.Format("2006-01-02"), tf.EndDate.Format("2006-01-02")))
+	return json.Marshal(fmt.Sprintf("%s,%s", tf.StartDate, tf.EndDate))
 }
 
 func (tf *Timeframe) UnmarshalJSON(data []byte) error {
@@ -118,10 +253,8 @@ func (tf *Timeframe) UnmarshalJSON(data []byte) error {
 		*tf = Timeframe{}
 		return nil
 	}
-	startTime, err1 := time.Parse("2006-01-02", dates[0])
-	startDate := ShortDate{startTime}
-	endTime, err2 := time.Parse("2006-01-02", dates[1])
-	endDate := ShortDate{endTime}
+	startDate, err1 := ParseShortDate(dates[0])
+	endDate, err2 := ParseShortDate(dates[1])
 	if err1 != nil || err2 != nil {
 		*tf = Timeframe{}
 		return nil
@@ -134,6 +267,11 @@ func (tf *Timeframe) IsZero() bool {
 	return tf.StartDate.IsZero() && tf.EndDate.IsZero()
 }
 
-func (tf *Timeframe) String() string {
-	return fmt.Sprintf("{%s-%s}", tf.StartDate, tf.EndDate)
+// String returns the timeframe as range expression understood by
+// ParseTimeframe, e.g. "2015-01-01..2015-02-07"
+func (tf Timeframe) String() string {
+	if tf.IsZero() {
+		return ""
+	}
+	return tf.StartDate.String() + timeframeRangeOp + tf.EndDate.String()
 }
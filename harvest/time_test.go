@@ -9,24 +9,50 @@ import (
 
 func TestNewShortDate(t *testing.T) {
 	dateTime := time.Date(2004, 02, 01, 5, 18, 47, 321, time.UTC)
-	expectedDate := time.Date(2004, 02, 01, 0, 0, 0, 0, time.UTC)
+	expectedDate := Date(2004, 02, 01)
 
 	shortDate := NewShortDate(dateTime)
 
-	if !reflect.DeepEqual(expectedDate, shortDate.Time) {
-		t.Logf("Expected date to equal '%s', got '%s'\n", expectedDate, shortDate.Time)
+	if !reflect.DeepEqual(expectedDate, shortDate) {
+		t.Logf("Expected date to equal '%s', got '%s'\n", expectedDate, shortDate)
+		t.Fail()
+	}
+
+	// 22:00 in New York is already the next day in UTC
+	newYork := mustLoad(time.LoadLocation("America/New_York"))
+	dateTime = time.Date(2004, 02, 01, 22, 0, 0, 0, newYork)
+
+	shortDate = NewShortDate(dateTime)
+
+	if !reflect.DeepEqual(expectedDate, shortDate) {
+		t.Logf("Expected date to equal '%s', got '%s'\n", expectedDate, shortDate)
+		t.Fail()
+	}
+
+	shortDate = NewShortDate(dateTime.In(time.UTC))
+
+	if !reflect.DeepEqual(Date(2004, 02, 02), shortDate) {
+		t.Logf("Expected date to equal '%s', got '%s'\n", Date(2004, 02, 02), shortDate)
 		t.Fail()
 	}
 
 }
 
 func TestDate(t *testing.T) {
-	date := time.Date(2004, 02, 01, 0, 0, 0, 0, time.UTC)
+	shortDate := Date(2004, 02, 01)
+
+	year, month, day := shortDate.Date()
+
+	if year != 2004 || month != time.February || day != 1 {
+		t.Logf("Expected date to equal 2004-02-01, got %d-%d-%d\n", year, month, day)
+		t.Fail()
+	}
 
-	shortDate := Date(2004, 02, 01, time.UTC)
+	// out of range values are normalized
+	shortDate = Date(2004, 01, 32)
 
-	if !reflect.DeepEqual(date, shortDate.Time) {
-		t.Logf("Expected date to equal '%s', got '%s'\n", date, shortDate.Time)
+	if !reflect.DeepEqual(Date(2004, 02, 01), shortDate) {
+		t.Logf("Expected date to equal '%s', got '%s'\n", Date(2004, 02, 01), shortDate)
 		t.Fail()
 	}
 }
@@ -48,13 +74,7 @@ func TestShortDateUnmarshalJSON(t *testing.T) {
 		t.Fail()
 	}
 
-	expectedDate, err := time.Parse("2006-01-02", "2014-02-01")
-	expectedShortDate := ShortDate{expectedDate}
-
-	if err != nil {
-		t.Logf("Expected error to be nil, got %T: %v\n", err, err)
-		t.Fail()
-	}
+	expectedShortDate := Date(2014, 02, 01)
 
 	if !reflect.DeepEqual(expectedShortDate, date) {
 		t.Logf("Expected date to be '%+#v', got '%+#v'\n", expectedShortDate, date)
@@ -63,7 +83,7 @@ func TestShortDateUnmarshalJSON(t *testing.T) {
 }
 
 func TestShortDateMarshalJSON(t *testing.T) {
-	date := ShortDate{time.Date(2014, time.February, 01, 0, 0, 0, 0, time.UTC)}
+	date := Date(2014, time.February, 01)
 
 	bytes, err := json.Marshal(&date)
 
@@ -99,38 +119,106 @@ func TestShortDateMarshalJSON(t *testing.T) {
 
 func TestFrom(t *testing.T) {
 	now := time.Now()
-	today := Date(now.Year(), now.Month(), now.Day(), now.Location())
 	locations := []*time.Location{
 		time.UTC,
 		mustLoad(time.LoadLocation("America/New_York")),
 		mustLoad(time.LoadLocation("Australia/Perth")),
 	}
 
+	for _, location := range locations {
+		expectedEndDate := NewShortDate(now.In(location))
+
+		actualEndDate := TimeframeFromDate(Date(2010, 02, 01), location).EndDate
+
+		if !reflect.DeepEqual(expectedEndDate, actualEndDate) {
+			t.Logf("Expected EndDate in %s to equal '%s', got '%s'\n", location, expectedEndDate, actualEndDate)
+			t.Fail()
+		}
+	}
+}
+
+func TestShortDateInAcrossDST(t *testing.T) {
+	newYork := mustLoad(time.LoadLocation("America/New_York"))
+	berlin := mustLoad(time.LoadLocation("Europe/Berlin"))
+
 	var tests = []struct {
-		startDate       ShortDate
-		expectedEndDate ShortDate
+		date           ShortDate
+		location       *time.Location
+		expectedLength time.Duration
 	}{
-		{
-			startDate:       Date(2010, 02, 01, locations[0]),
-			expectedEndDate: today,
-		},
-		{
-			startDate:       Date(2010, 02, 01, locations[1]),
-			expectedEndDate: Date(now.Year(), now.Month(), now.Day(), locations[1]),
-		},
-		{
-			startDate:       Date(2010, 02, 01, locations[2]),
-			expectedEndDate: Date(now.Year(), now.Month(), now.Day(), locations[2]),
-		},
+		// start of daylight saving time
+		{Date(2015, 3, 8), newYork, 23 * time.Hour},
+		{Date(2015, 3, 29), berlin, 23 * time.Hour},
+		// end of daylight saving time
+		{Date(2015, 11, 1), newYork, 25 * time.Hour},
+		{Date(2015, 10, 25), berlin, 25 * time.Hour},
+		{Date(2015, 10, 25), time.UTC, 24 * time.Hour},
 	}
-
 	for _, test := range tests {
-		actualEndDate := TimeframeFromDate(test.startDate).EndDate
-		if !reflect.DeepEqual(test.expectedEndDate, actualEndDate) {
-			t.Logf("Expected EndDate to equal '%s', got '%s'\n", test.expectedEndDate, actualEndDate)
+		start, end := Timeframe{StartDate: test.date, EndDate: test.date}.In(test.location)
+
+		if start.Hour() != 0 || start.Location() != test.location {
+			t.Logf("Expected %s to start at midnight in %s, got %s\n", test.date, test.location, start)
+			t.Fail()
+		}
+
+		if end.Sub(start) != test.expectedLength {
+			t.Logf("Expected %s to last %s in %s, got %s\n", test.date, test.expectedLength, test.location, end.Sub(start))
+			t.Fail()
+		}
+
+		if !reflect.DeepEqual(test.date, NewShortDate(start)) {
+			t.Logf("Expected start to be on %s, got %s\n", test.date, NewShortDate(start))
+			t.Fail()
+		}
+
+		// the last instant of the day is still on the same date
+		if !reflect.DeepEqual(test.date, NewShortDate(end.Add(-time.Nanosecond))) {
+			t.Logf("Expected end to be on %s, got %s\n", test.date, NewShortDate(end.Add(-time.Nanosecond)))
+			t.Fail()
+		}
+	}
+
+	// calendar arithmetic is not affected by daylight saving time
+	nextDay := Date(2015, 3, 7).AddDays(1)
+
+	if !reflect.DeepEqual(Date(2015, 3, 8), nextDay) {
+		t.Logf("Expected next day to equal %s, got %s\n", Date(2015, 3, 8), nextDay)
+		t.Fail()
+	}
+
+	days := NewTimeframe(2015, 3, 7, 2015, 3, 9).Days()
+
+	if len(days) != 3 {
+		t.Logf("Expected 3 days across DST, got %v\n", days)
+		t.Fail()
+	}
+}
+
+func TestShortDateUnmarshalJSONEmpty(t *testing.T) {
+	for _, data := range []string{`""`, `null`} {
+		date := Date(2015, 1, 1)
+
+		err := json.Unmarshal([]byte(data), &date)
+
+		if err != nil {
+			t.Logf("Expected no error for %s, got %T: %v\n", data, err, err)
+			t.Fail()
+		}
+
+		if !date.IsZero() {
+			t.Logf("Expected zero date for %s, got %s\n", data, date)
 			t.Fail()
 		}
 	}
+
+	var date ShortDate
+	err := json.Unmarshal([]byte(`"2015-02-30"`), &date)
+
+	if err == nil {
+		t.Logf("Expected error for invalid date, got nil\n")
+		t.Fail()
+	}
 }
 
 func mustLoad(loc *time.Location, err error) *time.Location {
@@ -141,11 +229,11 @@ func mustLoad(loc *time.Location, err error) *time.Location {
 }
 
 func TestNewTimeframe(t *testing.T) {
-	timeframe := NewTimeframe(2015, 1, 1, 2015, 2, 1, time.UTC)
+	timeframe := NewTimeframe(2015, 1, 1, 2015, 2, 1)
 
 	expectedTimeframe := Timeframe{
-		StartDate: Date(2015, 1, 1, time.UTC),
-		EndDate:   Date(2015, 2, 1, time.UTC),
+		StartDate: Date(2015, 1, 1),
+		EndDate:   Date(2015, 2, 1),
 	}
 
 	if !reflect.DeepEqual(expectedTimeframe, timeframe) {
@@ -161,33 +249,33 @@ func TestTimeframeIsInTimeframe(t *testing.T) {
 		isInTimeframe bool
 	}{
 		{
-			Date(2015, 1, 3, time.Local),
-			NewTimeframe(2015, 1, 1, 2015, 2, 1, time.Local),
+			Date(2015, 1, 3),
+			NewTimeframe(2015, 1, 1, 2015, 2, 1),
 			true,
 		},
 		{
-			Date(2015, 3, 1, time.Local),
-			NewTimeframe(2015, 1, 1, 2015, 2, 1, time.Local),
+			Date(2015, 3, 1),
+			NewTimeframe(2015, 1, 1, 2015, 2, 1),
 			false,
 		},
 		{
-			Date(2015, 2, 1, time.Local),
-			NewTimeframe(2015, 1, 1, 2015, 2, 1, time.Local),
+			Date(2015, 2, 1),
+			NewTimeframe(2015, 1, 1, 2015, 2, 1),
 			true,
 		},
 		{
-			ShortDate{time.Date(2015, 2, 1, 23, 59, 59, 999, time.Local)},
-			NewTimeframe(2015, 1, 1, 2015, 2, 1, time.Local),
+			NewShortDate(time.Date(2015, 2, 1, 23, 59, 59, 999, time.Local)),
+			NewTimeframe(2015, 1, 1, 2015, 2, 1),
 			true,
 		},
 		{
-			Date(2015, 1, 1, time.UTC),
-			NewTimeframe(2015, 1, 1, 2015, 2, 1, time.Local),
+			Date(2015, 1, 1),
+			NewTimeframe(2015, 1, 1, 2015, 2, 1),
 			true,
 		},
 		{
-			Date(2015, 2, 2, time.UTC),
-			NewTimeframe(2015, 1, 1, 2015, 2, 1, time.Local),
+			Date(2015, 2, 2),
+			NewTimeframe(2015, 1, 1, 2015, 2, 1),
 			false,
 		},
 	}
@@ -205,8 +293,8 @@ func TestTimeframeIsInTimeframe(t *testing.T) {
 }
 
 func TestTimeframeMarshalJSON(t *testing.T) {
-	startDate := ShortDate{time.Date(2014, time.February, 01, 0, 0, 0, 0, time.UTC)}
-	endDate := ShortDate{time.Date(2014, time.April, 01, 0, 0, 0, 0, time.UTC)}
+	startDate := Date(2014, time.February, 01)
+	endDate := Date(2014, time.April, 01)
 
 	var tests = []struct {
 		timeframe    Timeframe
@@ -246,8 +334,8 @@ func TestTimeframeMarshalJSON(t *testing.T) {
 }
 
 func TestTimeframeUnmarshalJSON(t *testing.T) {
-	startDate := ShortDate{time.Date(2014, time.February, 01, 0, 0, 0, 0, time.UTC)}
-	endDate := ShortDate{time.Date(2014, time.April, 01, 0, 0, 0, 0, time.UTC)}
+	startDate := Date(2014, time.February, 01)
+	endDate := Date(2014, time.April, 01)
 
 	var tests = []struct {
 		testJson          string
@@ -297,3 +385,59 @@ func TestTimeframeUnmarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestUserLocation(t *testing.T) {
+	var tests = []struct {
+		timezone     string
+		expectedName string
+		expectError  bool
+	}{
+		{"Eastern Time (US & Canada)", "America/New_York", false},
+		{"Berlin", "Europe/Berlin", false},
+		{"Australia/Perth", "Australia/Perth", false},
+		{"Middle Earth", "", true},
+		{"", "", true},
+	}
+	for _, test := range tests {
+		user := &User{ID: 1, Timezone: test.timezone}
+
+		location, err := user.Location()
+
+		if test.expectError {
+			if err == nil {
+				t.Logf("Expected error for %q, got nil\n", test.timezone)
+				t.Fail()
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Logf("Expected no error for %q, got %T: %v\n", test.timezone, err, err)
+			t.Fail()
+			continue
+		}
+
+		if location.String() != test.expectedName {
+			t.Logf("Expected location to equal %q, got %q\n", test.expectedName, location)
+			t.Fail()
+		}
+	}
+}
+
+func TestAccountUserLocation(t *testing.T) {
+	accountUser := &AccountUser{User: &User{Timezone: "Amsterdam"}, TimezoneUtcOffset: 3600}
+
+	if accountUser.Location().String() != "Europe/Amsterdam" {
+		t.Logf("Expected location to equal %q, got %q\n", "Europe/Amsterdam", accountUser.Location())
+		t.Fail()
+	}
+
+	accountUser = &AccountUser{User: &User{Timezone: "Middle Earth"}, TimezoneUtcOffset: -18000}
+
+	_, offset := time.Date(2015, 1, 1, 0, 0, 0, 0, accountUser.Location()).Zone()
+
+	if offset != -18000 {
+		t.Logf("Expected offset to equal %d, got %d\n", -18000, offset)
+		t.Fail()
+	}
+}
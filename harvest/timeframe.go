@@ -0,0 +1,134 @@
+package harvest
+
+import "time"
+
+// Period is a calendar unit used to split timeframes
+type Period int
+
+const (
+	PeriodDay Period = iota
+	PeriodWeek
+	PeriodMonth
+	PeriodQuarter
+	PeriodYear
+)
+
+// TimeframeForWeek returns the week containing date, starting at weekStart
+func TimeframeForWeek(date ShortDate, weekStart WeekStartDay) Timeframe {
+	offset := (int(date.Weekday()) - int(weekStart.Weekday()) + 7) % 7
+	start := date.AddDays(-offset)
+	return Timeframe{StartDate: start, EndDate: start.AddDays(6)}
+}
+
+// ThisWeek returns the week containing today, starting at weekStart
+func ThisWeek(today ShortDate, weekStart WeekStartDay) Timeframe {
+	return TimeframeForWeek(today, weekStart)
+}
+
+// LastWeek returns the week before the week containing today, starting at
+// weekStart
+func LastWeek(today ShortDate, weekStart WeekStartDay) Timeframe {
+	return TimeframeForWeek(today.AddDays(-7), weekStart)
+}
+
+// TimeframeForMonth returns the timeframe from the first to the last day of
+// the month
+func TimeframeForMonth(year int, month time.Month) Timeframe {
+	return timeframeForMonths(year, month, 1)
+}
+
+// TimeframeForQuarter returns the calendar quarter, where quarter is between
+// 1 and 4
+func TimeframeForQuarter(year int, quarter int) Timeframe {
+	return timeframeForMonths(year, time.Month(3*(quarter-1)+1), 3)
+}
+
+// TimeframeForYear returns the timeframe from the first of january to the
+// last of december of year
+func TimeframeForYear(year int) Timeframe {
+	return timeframeForMonths(year, time.January, 12)
+}
+
+// TimeframeForFiscalYear returns the fiscal year starting at the first of
+// startMonth in year, e.g. 2015-04-01 to 2016-03-31 for year 2015 and
+// startMonth time.April.
+func TimeframeForFiscalYear(year int, startMonth time.Month) Timeframe {
+	return timeframeForMonths(year, startMonth, 12)
+}
+
+// TimeframeForFiscalQuarter returns the quarter of the fiscal year returned
+// by TimeframeForFiscalYear, where quarter is between 1 and 4
+func TimeframeForFiscalQuarter(year int, quarter int, startMonth time.Month) Timeframe {
+	return timeframeForMonths(year, startMonth+time.Month(3*(quarter-1)), 3)
+}
+
+// timeframeForMonths returns the timeframe of months months starting at the
+// first of month. Months out of range are normalized like time.Date does.
+func timeframeForMonths(year int, month time.Month, months int) Timeframe {
+	start := Date(year, month, 1)
+	end := start.AddDate(0, months, -1)
+	return Timeframe{StartDate: start, EndDate: end}
+}
+
+// Contains returns true if date is within the timeframe, including its start
+// and end date
+func (tf Timeframe) Contains(date ShortDate) bool {
+	return compareDates(tf.StartDate, date) <= 0 && compareDates(date, tf.EndDate) <= 0
+}
+
+// Overlaps returns true if tf and other have at least one day in common
+func (tf Timeframe) Overlaps(other Timeframe) bool {
+	_, ok := tf.Intersect(other)
+	return ok
+}
+
+// Intersect returns the days tf and other have in common. It returns false
+// if the timeframes do not overlap.
+func (tf Timeframe) Intersect(other Timeframe) (Timeframe, bool) {
+	intersection := tf
+	if compareDates(other.StartDate, intersection.StartDate) > 0 {
+		intersection.StartDate = other.StartDate
+	}
+	if compareDates(other.EndDate, intersection.EndDate) < 0 {
+		intersection.EndDate = other.EndDate
+	}
+	if compareDates(intersection.StartDate, intersection.EndDate) > 0 {
+		return Timeframe{}, false
+	}
+	return intersection, true
+}
+
+// Days returns all dates within the timeframe in ascending order
+func (tf Timeframe) Days() []ShortDate {
+	var days []ShortDate
+	for day := tf.StartDate; compareDates(day, tf.EndDate) <= 0; day = day.AddDays(1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+// Split splits the timeframe at the calendar boundaries of period. The first
+// and last timeframe are cut to tf, so they may be shorter than period.
+// weekStart is only used for PeriodWeek.
+func (tf Timeframe) Split(period Period, weekStart WeekStartDay) []Timeframe {
+	var timeframes []Timeframe
+	for start := tf.StartDate; compareDates(start, tf.EndDate) <= 0; {
+		var chunk Timeframe
+		switch period {
+		case PeriodWeek:
+			chunk = TimeframeForWeek(start, weekStart)
+		case PeriodMonth:
+			chunk = TimeframeForMonth(start.Year(), start.Month())
+		case PeriodQuarter:
+			chunk = TimeframeForQuarter(start.Year(), quarterOf(start))
+		case PeriodYear:
+			chunk = TimeframeForYear(start.Year())
+		default:
+			chunk = Timeframe{StartDate: start, EndDate: start}
+		}
+		chunk, _ = chunk.Intersect(tf)
+		timeframes = append(timeframes, chunk)
+		start = chunk.EndDate.AddDays(1)
+	}
+	return timeframes
+}
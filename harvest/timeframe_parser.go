@@ -0,0 +1,142 @@
+package harvest
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	isoWeekPattern  = regexp.MustCompile(`^(\d{4})-W(\d{2})$`)
+	quarterPattern  = regexp.MustCompile(`^(\d{4})-Q([1-4])$`)
+	monthPattern    = regexp.MustCompile(`^(\d{4})-(\d{2})$`)
+	yearPattern     = regexp.MustCompile(`^(\d{4})$`)
+	relativePattern = regexp.MustCompile(`^-(\d+)([dw])$`)
+)
+
+// timeframeRangeOp separates the start and end of a timeframe expression
+const timeframeRangeOp = ".."
+
+// ParseTimeframe parses a timeframe expression relative to now. Supported
+// expressions are:
+//
+//	today, yesterday
+//	this-week, last-week, this-month, last-month,
+//	this-quarter, last-quarter, this-year, last-year
+//	2015-01-31 (a single day)
+//	2015-W07   (an ISO week)
+//	2015-Q1    (a calendar quarter)
+//	2015-03    (a month)
+//	2015       (a year)
+//	-30d, -2w  (the given number of days or weeks up to and including today)
+//	A..B       (from the start of expression A to the end of expression B)
+//
+// Weeks start at weekStart. An ISO week is mapped to the week containing its
+// monday.
+func ParseTimeframe(expression string, now time.Time, weekStart WeekStartDay) (Timeframe, error) {
+	expression = strings.TrimSpace(expression)
+	if parts := strings.Split(expression, timeframeRangeOp); len(parts) == 2 {
+		start, err := parseTimeframeExpression(parts[0], now, weekStart)
+		if err != nil {
+			return Timeframe{}, err
+		}
+		end, err := parseTimeframeExpression(parts[1], now, weekStart)
+		if err != nil {
+			return Timeframe{}, err
+		}
+		if compareDates(start.StartDate, end.EndDate) > 0 {
+			return Timeframe{}, fmt.Errorf("Malformed timeframe %q: start after end", expression)
+		}
+		return Timeframe{StartDate: start.StartDate, EndDate: end.EndDate}, nil
+	}
+	return parseTimeframeExpression(expression, now, weekStart)
+}
+
+func parseTimeframeExpression(expression string, now time.Time, weekStart WeekStartDay) (Timeframe, error) {
+	expression = strings.ToLower(strings.TrimSpace(expression))
+	today := NewShortDate(now)
+	switch expression {
+	case "today":
+		return Timeframe{StartDate: today, EndDate: today}, nil
+	case "yesterday":
+		yesterday := today.AddDays(-1)
+		return Timeframe{StartDate: yesterday, EndDate: yesterday}, nil
+	case "this-week":
+		return ThisWeek(today, weekStart), nil
+	case "last-week":
+		return LastWeek(today, weekStart), nil
+	case "this-month":
+		return TimeframeForMonth(today.Year(), today.Month()), nil
+	case "last-month":
+		return TimeframeForMonth(today.Year(), today.Month()-1), nil
+	case "this-quarter":
+		return TimeframeForQuarter(today.Year(), quarterOf(today)), nil
+	case "last-quarter":
+		return TimeframeForQuarter(today.Year(), quarterOf(today)-1), nil
+	case "this-year":
+		return TimeframeForYear(today.Year()), nil
+	case "last-year":
+		return TimeframeForYear(today.Year() - 1), nil
+	}
+	if date, err := time.Parse("2006-01-02", expression); err == nil {
+		day := NewShortDate(date)
+		return Timeframe{StartDate: day, EndDate: day}, nil
+	}
+	// the patterns are matched against the upper case expression, e.g. 2015-W07
+	upperExpression := strings.ToUpper(expression)
+	if match := isoWeekPattern.FindStringSubmatch(upperExpression); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		week, _ := strconv.Atoi(match[2])
+		monday, ok := isoWeekMonday(year, week)
+		if !ok {
+			return Timeframe{}, fmt.Errorf("Malformed timeframe %q: invalid week", expression)
+		}
+		return TimeframeForWeek(monday, weekStart), nil
+	}
+	if match := quarterPattern.FindStringSubmatch(upperExpression); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		quarter, _ := strconv.Atoi(match[2])
+		return TimeframeForQuarter(year, quarter), nil
+	}
+	if match := monthPattern.FindStringSubmatch(expression); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		month, _ := strconv.Atoi(match[2])
+		if month < 1 || month > 12 {
+			return Timeframe{}, fmt.Errorf("Malformed timeframe %q: invalid month", expression)
+		}
+		return TimeframeForMonth(year, time.Month(month)), nil
+	}
+	if match := yearPattern.FindStringSubmatch(expression); match != nil {
+		year, _ := strconv.Atoi(match[1])
+		return TimeframeForYear(year), nil
+	}
+	if match := relativePattern.FindStringSubmatch(expression); match != nil {
+		count, _ := strconv.Atoi(match[1])
+		if match[2] == "w" {
+			count *= 7
+		}
+		if count == 0 {
+			return Timeframe{}, fmt.Errorf("Malformed timeframe %q: empty range", expression)
+		}
+		return Timeframe{StartDate: today.AddDays(1 - count), EndDate: today}, nil
+	}
+	return Timeframe{}, fmt.Errorf("Malformed timeframe %q", expression)
+}
+
+// quarterOf returns the calendar quarter of date, between 1 and 4
+func quarterOf(date ShortDate) int {
+	return (int(date.Month())-1)/3 + 1
+}
+
+// isoWeekMonday returns the monday of the ISO week of year. It returns false
+// if year has no such week.
+func isoWeekMonday(year int, week int) (ShortDate, bool) {
+	// the 4th of january is always within the first ISO week
+	january4 := Date(year, time.January, 4)
+	offset := (int(january4.Weekday()) + 6) % 7
+	monday := january4.AddDays(-offset + 7*(week-1))
+	isoYear, isoWeek := monday.ISOWeek()
+	return monday, week >= 1 && isoYear == year && isoWeek == week
+}
@@ -0,0 +1,104 @@
+package harvest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseTimeframe(t *testing.T) {
+	// 2015-02-04 is a wednesday
+	now := time.Date(2015, 2, 4, 15, 30, 0, 0, time.UTC)
+
+	var tests = []struct {
+		expression string
+		weekStart  WeekStartDay
+		expected   Timeframe
+	}{
+		{"today", Monday, timeframe(2015, 2, 4, 2015, 2, 4)},
+		{"yesterday", Monday, timeframe(2015, 2, 3, 2015, 2, 3)},
+		{"this-week", Monday, timeframe(2015, 2, 2, 2015, 2, 8)},
+		{"last-week", Monday, timeframe(2015, 1, 26, 2015, 2, 1)},
+		{"last-week", Sunday, timeframe(2015, 1, 25, 2015, 1, 31)},
+		{"last-month", Monday, timeframe(2015, 1, 1, 2015, 1, 31)},
+		{"last-quarter", Monday, timeframe(2014, 10, 1, 2014, 12, 31)},
+		{"this-year", Monday, timeframe(2015, 1, 1, 2015, 12, 31)},
+		{"2015-W07", Monday, timeframe(2015, 2, 9, 2015, 2, 15)},
+		{"2015-w07", Sunday, timeframe(2015, 2, 8, 2015, 2, 14)},
+		{"2015-W01", Monday, timeframe(2014, 12, 29, 2015, 1, 4)},
+		{"2015-Q1", Monday, timeframe(2015, 1, 1, 2015, 3, 31)},
+		{"2015-03", Monday, timeframe(2015, 3, 1, 2015, 3, 31)},
+		{"2014", Monday, timeframe(2014, 1, 1, 2014, 12, 31)},
+		{"2015-01-15", Monday, timeframe(2015, 1, 15, 2015, 1, 15)},
+		{"2015-01-01..2015-02-07", Monday, timeframe(2015, 1, 1, 2015, 2, 7)},
+		{"2015-01..2015-Q1", Monday, timeframe(2015, 1, 1, 2015, 3, 31)},
+		{"-30d", Monday, timeframe(2015, 1, 6, 2015, 2, 4)},
+		{"-2w", Monday, timeframe(2015, 1, 22, 2015, 2, 4)},
+		{" Today ", Monday, timeframe(2015, 2, 4, 2015, 2, 4)},
+	}
+	for _, test := range tests {
+		tf, err := ParseTimeframe(test.expression, now, test.weekStart)
+
+		if err != nil {
+			t.Logf("Expected no error for %q, got %T: %v\n", test.expression, err, err)
+			t.Fail()
+			continue
+		}
+
+		if !reflect.DeepEqual(test.expected, tf) {
+			t.Logf("Expected %q to parse to %s, got %s\n", test.expression, test.expected, tf)
+			t.Fail()
+		}
+	}
+}
+
+func TestParseTimeframeErrors(t *testing.T) {
+	now := time.Date(2015, 2, 4, 15, 30, 0, 0, time.UTC)
+
+	expressions := []string{
+		"",
+		"tomorrow",
+		"2015-13",
+		"2015-W54",
+		"2015-W00",
+		"2015-Q5",
+		"-0d",
+		"2015-02-01..2015-01-01",
+		"2015-01-01..2015-02-01..2015-03-01",
+		"2015-01-01..",
+	}
+	for _, expression := range expressions {
+		_, err := ParseTimeframe(expression, now, Monday)
+
+		if err == nil {
+			t.Logf("Expected error for %q, got nil\n", expression)
+			t.Fail()
+		}
+	}
+}
+
+func TestTimeframeStringRoundTrip(t *testing.T) {
+	tf := timeframe(2015, 1, 1, 2015, 2, 7)
+
+	if tf.String() != "2015-01-01..2015-02-07" {
+		t.Logf("Expected timeframe string to equal %q, got %q\n", "2015-01-01..2015-02-07", tf.String())
+		t.Fail()
+	}
+
+	parsed, err := ParseTimeframe(tf.String(), time.Now(), Monday)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if !reflect.DeepEqual(tf, parsed) {
+		t.Logf("Expected parsed timeframe to equal %s, got %s\n", tf, parsed)
+		t.Fail()
+	}
+
+	if (Timeframe{}).String() != "" {
+		t.Logf("Expected zero timeframe string to be empty, got %q\n", Timeframe{}.String())
+		t.Fail()
+	}
+}
@@ -0,0 +1,153 @@
+package harvest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func timeframe(startYear int, startMonth time.Month, startDay int, endYear int, endMonth time.Month, endDay int) Timeframe {
+	return NewTimeframe(startYear, startMonth, startDay, endYear, endMonth, endDay)
+}
+
+func TestTimeframeConstructors(t *testing.T) {
+	// 2015-02-04 is a wednesday
+	today := Date(2015, 2, 4)
+
+	var tests = []struct {
+		name     string
+		actual   Timeframe
+		expected Timeframe
+	}{
+		{"ThisWeek Monday", ThisWeek(today, Monday), timeframe(2015, 2, 2, 2015, 2, 8)},
+		{"ThisWeek Sunday", ThisWeek(today, Sunday), timeframe(2015, 2, 1, 2015, 2, 7)},
+		{"ThisWeek Saturday", ThisWeek(today, Saturday), timeframe(2015, 1, 31, 2015, 2, 6)},
+		{"LastWeek Monday", LastWeek(today, Monday), timeframe(2015, 1, 26, 2015, 2, 1)},
+		{"Week on start day", TimeframeForWeek(Date(2015, 2, 2), Monday), timeframe(2015, 2, 2, 2015, 2, 8)},
+		{"Month", TimeframeForMonth(2016, time.February), timeframe(2016, 2, 1, 2016, 2, 29)},
+		{"Month december", TimeframeForMonth(2015, time.December), timeframe(2015, 12, 1, 2015, 12, 31)},
+		{"Quarter", TimeframeForQuarter(2015, 2), timeframe(2015, 4, 1, 2015, 6, 30)},
+		{"Year", TimeframeForYear(2015), timeframe(2015, 1, 1, 2015, 12, 31)},
+		{"FiscalYear", TimeframeForFiscalYear(2015, time.April), timeframe(2015, 4, 1, 2016, 3, 31)},
+		{"FiscalQuarter", TimeframeForFiscalQuarter(2015, 4, time.April), timeframe(2016, 1, 1, 2016, 3, 31)},
+	}
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.expected, test.actual) {
+			t.Logf("%s: expected timeframe to equal %s, got %s\n", test.name, &test.expected, &test.actual)
+			t.Fail()
+		}
+	}
+}
+
+func TestTimeframeContains(t *testing.T) {
+	tf := timeframe(2015, 1, 1, 2015, 1, 31)
+
+	var tests = []struct {
+		date     ShortDate
+		expected bool
+	}{
+		{Date(2015, 1, 1), true},
+		{Date(2015, 1, 15), true},
+		{Date(2015, 1, 31), true},
+		{NewShortDate(time.Date(2015, 1, 31, 23, 59, 0, 0, time.UTC)), true},
+		{Date(2014, 12, 31), false},
+		{Date(2015, 2, 1), false},
+	}
+	for _, test := range tests {
+		if tf.Contains(test.date) != test.expected {
+			t.Logf("Expected Contains(%s) to return %t\n", &test.date, test.expected)
+			t.Fail()
+		}
+	}
+}
+
+func TestTimeframeIntersect(t *testing.T) {
+	january := TimeframeForMonth(2015, time.January)
+
+	var tests = []struct {
+		other    Timeframe
+		expected Timeframe
+		overlaps bool
+	}{
+		{timeframe(2014, 12, 20, 2015, 1, 10), timeframe(2015, 1, 1, 2015, 1, 10), true},
+		{timeframe(2015, 1, 10, 2015, 1, 20), timeframe(2015, 1, 10, 2015, 1, 20), true},
+		{timeframe(2015, 1, 31, 2015, 2, 10), timeframe(2015, 1, 31, 2015, 1, 31), true},
+		{timeframe(2015, 2, 1, 2015, 2, 10), Timeframe{}, false},
+	}
+	for _, test := range tests {
+		intersection, ok := january.Intersect(test.other)
+
+		if ok != test.overlaps || january.Overlaps(test.other) != test.overlaps {
+			t.Logf("Expected overlap of %s to be %t\n", &test.other, test.overlaps)
+			t.Fail()
+		}
+
+		if !reflect.DeepEqual(test.expected, intersection) {
+			t.Logf("Expected intersection to equal %s, got %s\n", &test.expected, &intersection)
+			t.Fail()
+		}
+	}
+}
+
+func TestTimeframeDays(t *testing.T) {
+	days := timeframe(2015, 2, 27, 2015, 3, 2).Days()
+
+	expected := []ShortDate{
+		Date(2015, 2, 27),
+		Date(2015, 2, 28),
+		Date(2015, 3, 1),
+		Date(2015, 3, 2),
+	}
+
+	if !reflect.DeepEqual(expected, days) {
+		t.Logf("Expected days to equal %v, got %v\n", expected, days)
+		t.Fail()
+	}
+
+	days = timeframe(2015, 3, 2, 2015, 3, 1).Days()
+
+	if len(days) != 0 {
+		t.Logf("Expected no days for inverted timeframe, got %v\n", days)
+		t.Fail()
+	}
+}
+
+func TestTimeframeSplit(t *testing.T) {
+	tf := timeframe(2015, 1, 28, 2015, 3, 3)
+
+	weeks := tf.Split(PeriodWeek, Monday)
+
+	expectedWeeks := []Timeframe{
+		timeframe(2015, 1, 28, 2015, 2, 1),
+		timeframe(2015, 2, 2, 2015, 2, 8),
+		timeframe(2015, 2, 9, 2015, 2, 15),
+		timeframe(2015, 2, 16, 2015, 2, 22),
+		timeframe(2015, 2, 23, 2015, 3, 1),
+		timeframe(2015, 3, 2, 2015, 3, 3),
+	}
+
+	if !reflect.DeepEqual(expectedWeeks, weeks) {
+		t.Logf("Expected weeks to equal %v, got %v\n", expectedWeeks, weeks)
+		t.Fail()
+	}
+
+	months := tf.Split(PeriodMonth, Monday)
+
+	expectedMonths := []Timeframe{
+		timeframe(2015, 1, 28, 2015, 1, 31),
+		timeframe(2015, 2, 1, 2015, 2, 28),
+		timeframe(2015, 3, 1, 2015, 3, 3),
+	}
+
+	if !reflect.DeepEqual(expectedMonths, months) {
+		t.Logf("Expected months to equal %v, got %v\n", expectedMonths, months)
+		t.Fail()
+	}
+
+	days := timeframe(2015, 1, 1, 2015, 1, 3).Split(PeriodDay, Monday)
+
+	if len(days) != 3 {
+		t.Logf("Expected 3 days, got %d\n", len(days))
+		t.Fail()
+	}
+}
@@ -0,0 +1,27 @@
+package harvest
+
+import "time"
+
+// Timesheet represents the week of day entries a user submitted for approval.
+// It is only available for accounts with the approval feature.
+type Timesheet struct {
+	UserId int `json:"user-id"`
+	// the first day of the submitted week
+	WeekOf ShortDate `json:"week-of"`
+	// the sum of hours logged within the week
+	Hours       Hours     `json:"hours"`
+	DayEntryIds []int     `json:"day-entry-ids"`
+	SubmittedAt time.Time `json:"submitted-at"`
+	// JSON attributes not modeled by Timesheet
+	Extra ExtraFields `json:"-"`
+}
+
+func (t *Timesheet) ExtraFields() *ExtraFields {
+	return &t.Extra
+}
+
+type TimesheetActionPayload struct {
+	ErrorPayload
+	// the ids of the day entries affected by the action
+	DayEntryIds []int `json:"day-entry-ids,omitempty"`
+}
@@ -0,0 +1,77 @@
+package harvest
+
+import (
+	"fmt"
+	"time"
+)
+
+// railsTimezones maps the timezone names used by Harvest to IANA names
+var railsTimezones = map[string]string{
+	"Hawaii":                      "Pacific/Honolulu",
+	"Alaska":                      "America/Juneau",
+	"Pacific Time (US & Canada)":  "America/Los_Angeles",
+	"Arizona":                     "America/Phoenix",
+	"Mountain Time (US & Canada)": "America/Denver",
+	"Central Time (US & Canada)":  "America/Chicago",
+	"Eastern Time (US & Canada)":  "America/New_York",
+	"Atlantic Time (Canada)":      "America/Halifax",
+	"Brasilia":                    "America/Sao_Paulo",
+	"UTC":                         "Etc/UTC",
+	"London":                      "Europe/London",
+	"Dublin":                      "Europe/Dublin",
+	"Lisbon":                      "Europe/Lisbon",
+	"Amsterdam":                   "Europe/Amsterdam",
+	"Berlin":                      "Europe/Berlin",
+	"Bern":                        "Europe/Zurich",
+	"Brussels":                    "Europe/Brussels",
+	"Madrid":                      "Europe/Madrid",
+	"Paris":                       "Europe/Paris",
+	"Rome":                        "Europe/Rome",
+	"Stockholm":                   "Europe/Stockholm",
+	"Vienna":                      "Europe/Vienna",
+	"Warsaw":                      "Europe/Warsaw",
+	"Athens":                      "Europe/Athens",
+	"Helsinki":                    "Europe/Helsinki",
+	"Moscow":                      "Europe/Moscow",
+	"New Delhi":                   "Asia/Kolkata",
+	"Singapore":                   "Asia/Singapore",
+	"Hong Kong":                   "Asia/Hong_Kong",
+	"Tokyo":                       "Asia/Tokyo",
+	"Perth":                       "Australia/Perth",
+	"Sydney":                      "Australia/Sydney",
+	"Auckland":                    "Pacific/Auckland",
+}
+
+// loadLocation loads the location for name, which is either an IANA name or
+// one of the timezone names used by Harvest
+func loadLocation(name string) (*time.Location, error) {
+	if ianaName, ok := railsTimezones[name]; ok {
+		name = ianaName
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("Unknown timezone %q: %v", name, err)
+	}
+	return location, nil
+}
+
+// Location returns the timezone of the user. The Timezone may be an IANA name
+// or a timezone name as used by Harvest, e.g. "Eastern Time (US & Canada)".
+func (u *User) Location() (*time.Location, error) {
+	if u.Timezone == "" {
+		return nil, fmt.Errorf("No timezone set for user %d", u.ID)
+	}
+	return loadLocation(u.Timezone)
+}
+
+// Location returns the timezone of the account user. If the timezone can't
+// be resolved, a fixed zone with the TimezoneUtcOffset is returned, which does
+// not respect daylight saving time.
+func (a *AccountUser) Location() *time.Location {
+	if a.User != nil {
+		if location, err := a.User.Location(); err == nil {
+			return location
+		}
+	}
+	return time.FixedZone("", a.TimezoneUtcOffset)
+}
@@ -1,27 +1,39 @@
 package harvest
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"time"
 )
 
-//go:generate go run ../cmd/api_gen/api_gen.go -type=User -c -t -fields CrudEndpointProvider
+//go:generate go run ../cmd/api_gen/api_gen.go -type=User -c -t -fields "CrudEndpointProvider RequestProcessor"
 
 type User struct {
-	ID                           int       `json:"id,omitempty"`
-	Email                        string    `json:"email,omitempty"`
-	FirstName                    string    `json:"first_name,omitempty"`
-	LastName                     string    `json:"last_name,omitempty"`
-	HasAccessToAllFutureProjects bool      `json:"has_access_to_all_future_projects,omitempty"`
-	DefaultHourlyRate            float64   `json:"default_hourly_rate,omitempty"`
-	IsActive                     bool      `json:"is_active,omitempty"`
-	IsAdmin                      bool      `json:"is_admin,omitempty"`
-	IsContractor                 bool      `json:"is_contractor,omitempty"`
-	Telephone                    string    `json:"telephone,omitempty"`
-	Department                   string    `json:"department,omitempty"`
-	Timezone                     string    `json:"timezone,omitempty"`
-	UpdatedAt                    time.Time `json:"updated_at,omitempty"`
-	CreatedAt                    time.Time `json:"created_at,omitempty"`
+	ID                           int    `json:"id,omitempty"`
+	Email                        string `json:"email,omitempty"`
+	FirstName                    string `json:"first_name,omitempty"`
+	LastName                     string `json:"last_name,omitempty"`
+	HasAccessToAllFutureProjects bool   `json:"has_access_to_all_future_projects,omitempty"`
+	DefaultHourlyRate            *Money `json:"default_hourly_rate,omitempty"`
+	CostRate                     *Money `json:"cost_rate,omitempty"`
+	IsActive                     bool   `json:"is_active,omitempty"`
+	IsAdmin                      bool   `json:"is_admin,omitempty"`
+	IsContractor                 bool   `json:"is_contractor,omitempty"`
+	Telephone                    string `json:"telephone,omitempty"`
+	Department                   string `json:"department,omitempty"`
+	Timezone                     string `json:"timezone,omitempty"`
+	/* Project manager permissions. They are always sent to the API, so
+	that revoking a permission is possible. */
+	IsProjectManager  bool      `json:"is_project_manager"`
+	CanSeeRates       bool      `json:"can_see_rates"`
+	CanCreateProjects bool      `json:"can_create_projects"`
+	CanCreateInvoices bool      `json:"can_create_invoices"`
+	UpdatedAt         time.Time `json:"updated_at,omitempty"`
+	CreatedAt         time.Time `json:"created_at,omitempty"`
+	// JSON attributes not modeled by User
+	Extra ExtraFields `json:"-"`
 }
 
 func (u *User) Type() string {
@@ -36,6 +48,10 @@ func (u *User) SetId(id int) {
 	u.ID = id
 }
 
+func (u *User) ExtraFields() *ExtraFields {
+	return &u.Extra
+}
+
 func (u *User) ToggleActive() bool {
 	u.IsActive = !u.IsActive
 	return u.IsActive
@@ -51,7 +67,9 @@ func (u *UserService) DayEntries(user *User) *DayEntryService {
 	userPath := u.endpoint.Path()
 	path := fmt.Sprintf("%s/%d/entries", userPath, id)
 	endpoint := u.provider.CrudEndpoint(path)
-	return NewDayEntryService(endpoint)
+	service := NewDayEntryService(endpoint)
+	service.scope = userScope
+	return service
 }
 
 func (u *UserService) Expenses(user *User) *ExpenseService {
@@ -61,3 +79,29 @@ func (u *UserService) Expenses(user *User) *ExpenseService {
 	endpoint := u.provider.CrudEndpoint(path)
 	return NewExpenseService(endpoint)
 }
+
+// ResetPassword triggers a password reset for the given user. Harvest will
+// send an email with reset instructions to the user.
+func (u *UserService) ResetPassword(user *User) error {
+	id := user.Id()
+	userPath := u.endpoint.Path()
+	path := fmt.Sprintf("%s/%d/reset_password", userPath, id)
+	response, err := u.processor.Process("POST", path, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		responseBytes, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		apiResponse := ErrorPayload{}
+		err = json.Unmarshal(responseBytes, &apiResponse)
+		if err != nil {
+			return err
+		}
+		return &ResponseError{&apiResponse}
+	}
+	return nil
+}
@@ -11,9 +11,13 @@ type UserAssignment struct {
 	// If true, user cannot log more hours toward the project -->
 	Deactivated bool `json:"deactivated"`
 	// Hourly rate of user on current project -->
-	HourlyRate float64   `json:"hourly-rate"`
-	UpdatedAt  time.Time `json:"updated_at,omitempty"`
-	CreatedAt  time.Time `json:"created_at,omitempty"`
+	HourlyRate Money `json:"hourly-rate"`
+	// The budget in hours (if present) for the user in project
+	Budget    float64   `json:"budget"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// JSON attributes not modeled by UserAssignment
+	Extra ExtraFields `json:"-"`
 }
 
 func (u *UserAssignment) Id() int {
@@ -24,6 +28,10 @@ func (u *UserAssignment) SetId(id int) {
 	u.ID = id
 }
 
+func (u *UserAssignment) ExtraFields() *ExtraFields {
+	return &u.Extra
+}
+
 func (u *UserAssignment) Type() string {
 	return "user-assignment"
 }
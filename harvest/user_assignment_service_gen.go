@@ -17,22 +17,37 @@ func NewUserAssignmentService(endpoint CrudEndpoint) *UserAssignmentService {
 	return &service
 }
 
+// Typed returns the typed service for userassignments
+func (s *UserAssignmentService) Typed() *Service[UserAssignment, *UserAssignment] {
+	return NewService[UserAssignment](s.endpoint)
+}
+
 func (s *UserAssignmentService) All(userassignments *[]*UserAssignment, params url.Values) error {
-	return s.endpoint.All(userassignments, params)
+	all, err := s.Typed().All(params)
+	if all != nil {
+		*userassignments = all
+	}
+	return err
 }
 
 func (s *UserAssignmentService) Find(id int, userassignment *UserAssignment, params url.Values) error {
-	return s.endpoint.Find(id, userassignment, params)
+	found, err := s.Typed().Find(id, params)
+	if found != nil {
+		*userassignment = *found
+	}
+	return err
 }
 
 func (s *UserAssignmentService) Create(userassignment *UserAssignment) error {
-	return s.endpoint.Create(userassignment)
+	return s.Typed().Create(userassignment)
 }
 
-func (s *UserAssignmentService) Update(userassignment *UserAssignment) error {
-	return s.endpoint.Update(userassignment)
+// Update updates the given userassignment. If fields are provided, only these
+// fields are sent to the API, see NewPatch.
+func (s *UserAssignmentService) Update(userassignment *UserAssignment, fields ...string) error {
+	return s.Typed().Update(userassignment, fields...)
 }
 
 func (s *UserAssignmentService) Delete(userassignment *UserAssignment) error {
-	return s.endpoint.Delete(userassignment)
+	return s.Typed().Delete(userassignment)
 }
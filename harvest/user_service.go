@@ -28,7 +28,7 @@ func (s *UserService) AllUpdatedSince(updatedSince time.Time) ([]*User, error) {
 	peopleUrl := "/people"
 	if !updatedSince.IsZero() {
 		values := make(url.Values)
-		values.Add("updated_since", updatedSince.UTC().String())
+		values.Add("updated_since", updatedSince.UTC().Format(UpdatedSinceFormat))
 		peopleUrl = peopleUrl + "?" + values.Encode()
 	}
 	response, err := s.api.Process("GET", peopleUrl, nil)
@@ -12,36 +12,53 @@ import (
 type UserService struct {
 	provider	CrudEndpointProvider
 	endpoint	CrudTogglerEndpoint
+	processor	RequestProcessor
 }
 
-func NewUserService(provider CrudEndpointProvider, endpoint CrudTogglerEndpoint) *UserService {
+func NewUserService(provider CrudEndpointProvider, endpoint CrudTogglerEndpoint, processor RequestProcessor) *UserService {
 	service := UserService{
 		provider:	provider,
 		endpoint:	endpoint,
+		processor:	processor,
 	}
 	return &service
 }
 
+// Typed returns the typed service for users
+func (s *UserService) Typed() *TogglerService[User, *User] {
+	return NewTogglerService[User](s.endpoint)
+}
+
 func (s *UserService) All(users *[]*User, params url.Values) error {
-	return s.endpoint.All(users, params)
+	all, err := s.Typed().All(params)
+	if all != nil {
+		*users = all
+	}
+	return err
 }
 
 func (s *UserService) Find(id int, user *User, params url.Values) error {
-	return s.endpoint.Find(id, user, params)
+	found, err := s.Typed().Find(id, params)
+	if found != nil {
+		*user = *found
+	}
+	return err
 }
 
 func (s *UserService) Create(user *User) error {
-	return s.endpoint.Create(user)
+	return s.Typed().Create(user)
 }
 
-func (s *UserService) Update(user *User) error {
-	return s.endpoint.Update(user)
+// Update updates the given user. If fields are provided, only these
+// fields are sent to the API, see NewPatch.
+func (s *UserService) Update(user *User, fields ...string) error {
+	return s.Typed().Update(user, fields...)
 }
 
 func (s *UserService) Delete(user *User) error {
-	return s.endpoint.Delete(user)
+	return s.Typed().Delete(user)
 }
 
 func (s *UserService) Toggle(user *User) error {
-	return s.endpoint.Toggle(user)
+	return s.Typed().Toggle(user)
 }
@@ -1,6 +1,13 @@
 package harvest
 
-import "testing"
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
 
 func TestUserSetId(t *testing.T) {
 	user := &User{}
@@ -59,3 +66,90 @@ func TestUserType(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestUserMarshalJSONPermissions(t *testing.T) {
+	user := &User{ID: 12, IsProjectManager: true}
+
+	marshaled, err := json.Marshal(user)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	var fields map[string]interface{}
+	json.Unmarshal(marshaled, &fields)
+
+	expectedPermissions := map[string]bool{
+		"is_project_manager":  true,
+		"can_see_rates":       false,
+		"can_create_projects": false,
+		"can_create_invoices": false,
+	}
+
+	for field, expected := range expectedPermissions {
+		value, ok := fields[field]
+		if !ok {
+			t.Logf("Expected field %q to be marshaled, was not\n", field)
+			t.Fail()
+			continue
+		}
+		if value != expected {
+			t.Logf("Expected field %q to equal %t, got %v\n", field, expected, value)
+			t.Fail()
+		}
+	}
+}
+
+func TestUserServiceResetPassword(t *testing.T) {
+	processor := &testProcessor{
+		response: &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(emptyReader()),
+		},
+	}
+	service := NewUserService(nil, &JsonApi{path: "people"}, processor)
+
+	err := service.ResetPassword(&User{ID: 12})
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if processor.method != "POST" {
+		t.Logf("Expected request method to equal 'POST', got %q\n", processor.method)
+		t.Fail()
+	}
+
+	if processor.path != "people/12/reset_password" {
+		t.Logf("Expected request path to equal 'people/12/reset_password', got %q\n", processor.path)
+		t.Fail()
+	}
+
+	// API error
+	processor.response = &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"message":"User is not active"}`)),
+	}
+
+	err = service.ResetPassword(&User{ID: 12})
+
+	if err == nil {
+		t.Logf("Expected error, got nil\n")
+		t.Fail()
+	} else if err.Error() != "User is not active" {
+		t.Logf("Expected error message to equal %q, got %q\n", "User is not active", err.Error())
+		t.Fail()
+	}
+
+	// HTTP error
+	processor.err = fmt.Errorf("HTTP DOWN")
+
+	err = service.ResetPassword(&User{ID: 12})
+
+	if err == nil || err.Error() != "HTTP DOWN" {
+		t.Logf("Expected error with message 'HTTP DOWN', got %v\n", err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,135 @@
+package harvest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Validator is implemented by models which can check their data before they
+// are sent to the API. JsonApi.Create and JsonApi.Update call Validate and
+// abort with the returned error.
+//
+// Unknown enum values are not reported by Validate, as they may have been
+// decoded from the API. JsonApi.Create rejects them in addition.
+type Validator interface {
+	Validate() error
+}
+
+// FieldError describes why the value of a single field is invalid. Field is
+// the JSON name of the field.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (f *FieldError) Error() string {
+	return fmt.Sprintf("%s %s", f.Field, f.Message)
+}
+
+// ValidationError is returned if a model fails its validation. It contains an
+// error for every invalid field.
+type ValidationError struct {
+	Type   string
+	Errors []*FieldError
+}
+
+func (v *ValidationError) Error() string {
+	messages := make([]string, len(v.Errors))
+	for i, e := range v.Errors {
+		messages[i] = e.Error()
+	}
+	return fmt.Sprintf("Invalid %s: %s", v.Type, strings.Join(messages, ", "))
+}
+
+// add adds a FieldError for field to the ValidationError
+func (v *ValidationError) add(field string, format string, args ...interface{}) {
+	v.Errors = append(v.Errors, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// errorOrNil returns the ValidationError if it contains any FieldError and
+// nil otherwise
+func (v *ValidationError) errorOrNil() error {
+	if len(v.Errors) == 0 {
+		return nil
+	}
+	return v
+}
+
+// validate calls Validate on data if it implements Validator
+func validate(data interface{}) error {
+	if validator, ok := data.(Validator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+// marshalEnum marshals the value of an enum type to JSON. Unknown values are
+// passed through, so that models decoded with values added by the API can be
+// sent back.
+func marshalEnum(value string) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// unmarshalEnum unmarshals the JSON string data and returns it. Unknown values
+// are kept, so that new values added by the API do not break decoding. They
+// are reported by JsonApi.Create and, in strict mode, by JsonApi.Find and
+// JsonApi.All.
+func unmarshalEnum(data []byte) (string, error) {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// enum is implemented by the enum types of the models
+type enum interface {
+	IsValid() bool
+}
+
+// unknownEnumValues returns a ValidationError for all enum fields of data
+// holding an unknown value, or nil if there are none. data may be a pointer to
+// a model or to a slice of models.
+func unknownEnumValues(data interface{}) error {
+	validationError := &ValidationError{Type: typeName(data)}
+	found := make(map[FieldError]bool)
+	dataValue := reflect.ValueOf(data)
+	for dataValue.Kind() == reflect.Ptr && !dataValue.IsNil() {
+		dataValue = dataValue.Elem()
+	}
+	models := []reflect.Value{dataValue}
+	if dataValue.Kind() == reflect.Slice {
+		models = models[:0]
+		for i := 0; i < dataValue.Len(); i++ {
+			models = append(models, dataValue.Index(i))
+		}
+	}
+	for _, model := range models {
+		for model.Kind() == reflect.Ptr && !model.IsNil() {
+			model = model.Elem()
+		}
+		if model.Kind() != reflect.Struct {
+			continue
+		}
+		for i := 0; i < model.NumField(); i++ {
+			field := model.Type().Field(i)
+			name := jsonFieldName(field)
+			if field.PkgPath != "" || name == "" {
+				continue
+			}
+			value, ok := model.Field(i).Interface().(enum)
+			if !ok || value.IsValid() {
+				continue
+			}
+			fieldError := FieldError{Field: name, Message: fmt.Sprintf("has unknown value %q", value)}
+			if !found[fieldError] {
+				found[fieldError] = true
+				validationError.Errors = append(validationError.Errors, &fieldError)
+			}
+		}
+	}
+	return validationError.errorOrNil()
+}
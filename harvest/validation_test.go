@@ -0,0 +1,337 @@
+package harvest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEnumMarshalJSON(t *testing.T) {
+	var tests = []struct {
+		value    interface{}
+		expected string
+	}{
+		{BillByTasks, `"Tasks"`},
+		{BillByProject, `"Project"`},
+		{BillBy("foo"), `"foo"`},
+		{BudgetByProjectCost, `"project_cost"`},
+		{BudgetBy("Tasks"), `"Tasks"`},
+		{DetailedInvoice, `"detailed"`},
+		{InvoiceKind("bar"), `"bar"`},
+		{PartialInvoice, `"partial"`},
+		{InvoiceState("unpaid"), `"unpaid"`},
+	}
+	for _, test := range tests {
+		marshaled, err := json.Marshal(test.value)
+
+		if err != nil {
+			t.Logf("Expected no error for %T(%v), got %T: %v\n", test.value, test.value, err, err)
+			t.Fail()
+		}
+
+		if string(marshaled) != test.expected {
+			t.Logf("Expected %T(%v) to marshal to %s, got %s\n", test.value, test.value, test.expected, marshaled)
+			t.Fail()
+		}
+	}
+}
+
+func TestEnumUnmarshalJSON(t *testing.T) {
+	var project Project
+
+	err := json.Unmarshal([]byte(`{"bill_by":"People","budget_by":"person"}`), &project)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if project.BillBy != BillByPeople {
+		t.Logf("Expected BillBy to equal %q, got %q\n", BillByPeople, project.BillBy)
+		t.Fail()
+	}
+
+	if project.BudgetBy != BudgetByPerson {
+		t.Logf("Expected BudgetBy to equal %q, got %q\n", BudgetByPerson, project.BudgetBy)
+		t.Fail()
+	}
+
+	// Unknown values are kept
+	err = json.Unmarshal([]byte(`{"bill_by":"Persons","budget_by":"people"}`), &project)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if project.BillBy != "Persons" || project.BudgetBy != "people" {
+		t.Logf("Expected unknown values to be kept, got %q and %q\n", project.BillBy, project.BudgetBy)
+		t.Fail()
+	}
+
+	if err := project.Validate(); err != nil {
+		t.Logf("Expected unknown values to pass validation, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	var invoice Invoice
+
+	err = json.Unmarshal([]byte(`{"kind":"free_form","state":"draft"}`), &invoice)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if invoice.Kind != FreeFormInvoice || invoice.State != DraftInvoice {
+		t.Logf("Expected kind and state to equal %q and %q, got %q and %q\n", FreeFormInvoice, DraftInvoice, invoice.Kind, invoice.State)
+		t.Fail()
+	}
+
+	err = json.Unmarshal([]byte(`{"state":"pastdue"}`), &invoice)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if invoice.State != "pastdue" {
+		t.Logf("Expected unknown state to be kept, got %q\n", invoice.State)
+		t.Fail()
+	}
+}
+
+func TestUnknownEnumValues(t *testing.T) {
+	projects := []*Project{
+		{BillBy: "Persons"},
+		{BillBy: BillByTasks, BudgetBy: "people"},
+		{BillBy: "Persons"},
+	}
+
+	err := unknownEnumValues(&projects)
+
+	expected := &ValidationError{
+		Type: "Project",
+		Errors: []*FieldError{
+			{Field: "bill_by", Message: `has unknown value "Persons"`},
+			{Field: "budget_by", Message: `has unknown value "people"`},
+		},
+	}
+
+	if !reflect.DeepEqual(expected, err) {
+		t.Logf("Expected error to equal %+#v, got %+#v\n", expected, err)
+		t.Fail()
+	}
+
+	err = unknownEnumValues(&Project{BillBy: BillByNone})
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	// unexported fields are skipped
+	err = unknownEnumValues(&struct {
+		kind InvoiceKind
+		Kind InvoiceKind `json:"kind"`
+	}{kind: "foo", Kind: ProjectInvoice})
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+}
+
+func TestProjectValidate(t *testing.T) {
+	project := &Project{BillBy: BillByNone, BudgetBy: BudgetByNone}
+
+	err := project.Validate()
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	project = &Project{
+		BillBy:                           "Persons",
+		BudgetBy:                         "hours",
+		Budget:                           -1,
+		OverBudgetNotificationPercentage: 120,
+	}
+
+	err = project.Validate()
+
+	validationError, ok := err.(*ValidationError)
+	if !ok {
+		t.Logf("Expected error of type *ValidationError, got %T\n", err)
+		t.FailNow()
+	}
+
+	var fields []string
+	for _, fieldError := range validationError.Errors {
+		fields = append(fields, fieldError.Field)
+	}
+
+	expectedFields := []string{"budget", "over_budget_notification_percentage"}
+
+	if !reflect.DeepEqual(expectedFields, fields) {
+		t.Logf("Expected errors for fields %v, got %v\n", expectedFields, fields)
+		t.Fail()
+	}
+}
+
+func TestInvoiceValidate(t *testing.T) {
+	invoice := &Invoice{Kind: ProjectInvoice, State: OpenInvoice, Tax: 19}
+
+	err := invoice.Validate()
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	invoice = &Invoice{Kind: "hours", State: "unpaid", Tax2: -3}
+
+	err = invoice.Validate()
+
+	validationError, ok := err.(*ValidationError)
+	if !ok {
+		t.Logf("Expected error of type *ValidationError, got %T\n", err)
+		t.FailNow()
+	}
+
+	if len(validationError.Errors) != 1 || validationError.Errors[0].Field != "tax2" {
+		t.Logf("Expected only an error for tax2, got %v\n", validationError)
+		t.Fail()
+	}
+}
+
+func TestValidationErrorError(t *testing.T) {
+	validationError := &ValidationError{Type: "Project"}
+	validationError.add("bill_by", "must be one of %s", "Tasks")
+	validationError.add("budget", "must not be negative")
+
+	expected := "Invalid Project: bill_by must be one of Tasks, budget must not be negative"
+
+	if validationError.Error() != expected {
+		t.Logf("Expected error message to equal %q, got %q\n", expected, validationError.Error())
+		t.Fail()
+	}
+}
+
+func TestJsonApiCreateUpdateValidate(t *testing.T) {
+	testClient := &testHttpClient{}
+	api := createJsonTestApi(testClient)
+	project := &Project{ID: 12, Name: "Foo", BillBy: "Persons"}
+
+	err := api.Create(project)
+
+	if _, ok := err.(*ValidationError); !ok {
+		t.Logf("Expected error of type *ValidationError, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	project = &Project{ID: 12, Name: "Foo", Budget: -1}
+
+	err = api.Update(project)
+
+	if _, ok := err.(*ValidationError); !ok {
+		t.Logf("Expected error of type *ValidationError, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if testClient.testRequest != nil {
+		t.Logf("Expected no request to be sent, got %+#v\n", testClient.testRequest)
+		t.Fail()
+	}
+}
+
+func TestPatchValidate(t *testing.T) {
+	project := &Project{ID: 12, Budget: -1, OverBudgetNotificationPercentage: 120}
+
+	err := NewPatch(project, "name").Validate()
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	err = NewPatch(project, "Budget").Validate()
+
+	validationError, ok := err.(*ValidationError)
+	if !ok {
+		t.Logf("Expected error of type *ValidationError, got %T\n", err)
+		t.FailNow()
+	}
+
+	if len(validationError.Errors) != 1 || validationError.Errors[0].Field != "budget" {
+		t.Logf("Expected only an error for budget, got %v\n", validationError)
+		t.Fail()
+	}
+}
+
+func TestJsonApiUpdateUnknownEnumValues(t *testing.T) {
+	testClient := &testHttpClient{}
+	api := createJsonTestApi(testClient)
+	testClient.setResponseBody(http.StatusOK, strings.NewReader(`{"project":{"id":12,"name":"Foo","bill_by":"Milestones","budget_by":"phase"}}`))
+
+	var project Project
+
+	err := api.Find(12, &project, nil)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	project.Name = "Bar"
+	testClient.setResponsePayload(http.StatusOK, nil, nil, "")
+
+	err = api.Update(&project)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.FailNow()
+	}
+
+	body := panicErr(ioutil.ReadAll(testClient.testRequest.Body)).([]byte)
+	for _, expected := range []string{`"name":"Bar"`, `"bill_by":"Milestones"`, `"budget_by":"phase"`} {
+		if !strings.Contains(string(body), expected) {
+			t.Logf("Expected request body to contain %s, got %s\n", expected, body)
+			t.Fail()
+		}
+	}
+}
+
+func TestJsonApiStrictModeUnknownEnumValues(t *testing.T) {
+	testClient := &testHttpClient{}
+	api := createJsonTestApi(testClient)
+	testClient.setResponseBody(http.StatusOK, strings.NewReader(`{"project":{"id":12,"bill_by":"Milestones"}}`))
+
+	var project Project
+
+	err := api.Find(12, &project, nil)
+
+	if err != nil {
+		t.Logf("Expected no error, got %T: %v\n", err, err)
+		t.Fail()
+	}
+
+	if project.BillBy != "Milestones" {
+		t.Logf("Expected unknown value to be kept, got %q\n", project.BillBy)
+		t.Fail()
+	}
+
+	api.SetStrict(true)
+	testClient.setResponseBody(http.StatusOK, strings.NewReader(`{"project":{"id":12,"bill_by":"Milestones"}}`))
+
+	err = api.Find(12, &project, nil)
+
+	if _, ok := err.(*ValidationError); !ok {
+		t.Logf("Expected error of type *ValidationError, got %T: %v\n", err, err)
+		t.Fail()
+	}
+}